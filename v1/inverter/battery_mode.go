@@ -0,0 +1,176 @@
+package inverter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BatteryMode is one of the operating modes presented by the GivEnergy app,
+// each a combination of the eco, DC discharge and pause settings.
+type BatteryMode string
+
+const (
+	// BatteryModeEco charges from excess solar and discharges to cover demand.
+	BatteryModeEco BatteryMode = "eco"
+	// BatteryModeTimedDemand behaves like eco but only discharges to cover
+	// demand during the discharge slots.
+	BatteryModeTimedDemand BatteryMode = "timed_demand"
+	// BatteryModeTimedExport discharges at full power during the discharge
+	// slots, exporting what the home does not use.
+	BatteryModeTimedExport BatteryMode = "timed_export"
+	// BatteryModePaused neither charges nor discharges the battery.
+	BatteryModePaused BatteryMode = "paused"
+	// BatteryModeCustom is reported when the settings match no other mode.
+	// It cannot be set.
+	BatteryModeCustom BatteryMode = "custom"
+)
+
+// BatteryPause is the value of the Pause Battery setting.
+type BatteryPause int
+
+const (
+	BatteryPauseNone BatteryPause = iota
+	BatteryPauseCharge
+	BatteryPauseDischarge
+	BatteryPauseChargeAndDischarge
+)
+
+// GetBatteryMode derives the battery mode from the eco, DC discharge and
+// pause settings of the inverter.
+func (c *Client) GetBatteryMode(ctx context.Context, serialNumber string) (BatteryMode, error) {
+	pause, err := c.readBatteryPause(ctx, serialNumber)
+	if err != nil {
+		return "", err
+	}
+	if pause != nil && *pause != BatteryPauseNone {
+		return BatteryModePaused, nil
+	}
+
+	eco, discharge, err := c.readBatteryToggles(ctx, serialNumber)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case eco && !discharge:
+		return BatteryModeEco, nil
+	case eco && discharge:
+		return BatteryModeTimedDemand, nil
+	case discharge:
+		return BatteryModeTimedExport, nil
+	default:
+		return BatteryModeCustom, nil
+	}
+}
+
+// SetBatteryMode writes every setting making up the mode. The timed modes
+// use the discharge slots, see WriteDischargeWindow and WriteSchedule.
+func (c *Client) SetBatteryMode(ctx context.Context, serialNumber string, mode BatteryMode) error {
+	var eco, discharge bool
+	switch mode {
+	case BatteryModeEco:
+		eco = true
+	case BatteryModeTimedDemand:
+		eco, discharge = true, true
+	case BatteryModeTimedExport:
+		discharge = true
+	case BatteryModePaused:
+		return c.writeBatteryPause(ctx, serialNumber, BatteryPauseChargeAndDischarge)
+	default:
+		return fmt.Errorf("cannot set battery mode %q", mode)
+	}
+
+	if err := c.writeBatteryToggles(ctx, serialNumber, eco, discharge); err != nil {
+		return err
+	}
+	return c.writeBatteryPause(ctx, serialNumber, BatteryPauseNone)
+}
+
+// readBatteryToggles reads the eco and DC discharge settings. DC discharge is
+// reported as disabled on inverters without it.
+func (c *Client) readBatteryToggles(ctx context.Context, serialNumber string) (eco, discharge bool, err error) {
+	if err := c.requireCapability(ctx, serialNumber, CapabilityEcoMode); err != nil {
+		return false, false, err
+	}
+	ecoRes, err := ReadSettingByName[bool](ctx, c, serialNumber, SettingNameEcoModeEnabled)
+	if err != nil {
+		return false, false, err
+	}
+
+	err = c.requireCapability(ctx, serialNumber, CapabilityDCDischarge)
+	if err == nil {
+		var res *ReadSettingResponse[bool]
+		res, err = ReadSettingByName[bool](ctx, c, serialNumber, SettingNameDischargeEnabled)
+		if err == nil {
+			discharge = res.Data.Value
+		}
+	}
+	if err != nil && !errors.Is(err, ErrUnsupported) && !errors.Is(err, ErrSettingNotFound) {
+		return false, false, err
+	}
+
+	return ecoRes.Data.Value, discharge, nil
+}
+
+// writeBatteryToggles writes the eco and DC discharge settings.
+func (c *Client) writeBatteryToggles(ctx context.Context, serialNumber string, eco, discharge bool) error {
+	if err := c.requireCapability(ctx, serialNumber, CapabilityEcoMode); err != nil {
+		return err
+	}
+	if _, err := WriteSettingByName(ctx, c, serialNumber, SettingNameEcoModeEnabled, eco); err != nil {
+		return err
+	}
+
+	err := c.requireCapability(ctx, serialNumber, CapabilityDCDischarge)
+	if err == nil {
+		_, err = WriteSettingByName(ctx, c, serialNumber, SettingNameDischargeEnabled, discharge)
+	}
+	// Disabling DC discharge on an inverter without it is a no-op.
+	if err != nil && (discharge || !errors.Is(err, ErrUnsupported) && !errors.Is(err, ErrSettingNotFound)) {
+		return err
+	}
+	return nil
+}
+
+// readBatteryPause returns nil when the inverter has no Pause Battery setting.
+func (c *Client) readBatteryPause(ctx context.Context, serialNumber string) (*BatteryPause, error) {
+	id, err := c.pauseSettingID(ctx, serialNumber)
+	if err != nil || id == "" {
+		return nil, err
+	}
+
+	res, err := ReadSetting[BatteryPause](ctx, c, NewReadSettingArgs(serialNumber, id))
+	if err != nil {
+		return nil, err
+	}
+	return &res.Data.Value, nil
+}
+
+// writeBatteryPause writes the Pause Battery setting. Clearing the pause on
+// an inverter without the setting is a no-op.
+func (c *Client) writeBatteryPause(ctx context.Context, serialNumber string, value BatteryPause) error {
+	id, err := c.pauseSettingID(ctx, serialNumber)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		if value == BatteryPauseNone {
+			return nil
+		}
+		return fmt.Errorf("inverter %s: %s: %w", serialNumber, SettingNamePauseBattery, ErrUnsupported)
+	}
+
+	_, err = WriteSetting(ctx, c, NewWriteSettingArgs(serialNumber, id, value))
+	return err
+}
+
+// pauseSettingID returns an empty ID when the inverter has no Pause Battery
+// setting.
+func (c *Client) pauseSettingID(ctx context.Context, serialNumber string) (string, error) {
+	id, err := c.ResolveSettingID(ctx, serialNumber, SettingNamePauseBattery)
+	if errors.Is(err, ErrSettingNotFound) {
+		return "", nil
+	}
+	return id, err
+}
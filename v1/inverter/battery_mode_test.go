@@ -0,0 +1,176 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func batteryModeRoutes(routes ...*mockRoute) []*mockRoute {
+	return append([]*mockRoute{
+		{
+			URL:        fmt.Sprintf("%s/inverter/%s/settings", baseURL, "inverter-1"),
+			Path:       "testdata/list_settings_catalog_200.json",
+			StatusCode: http.StatusOK,
+		},
+	}, routes...)
+}
+
+func TestClient_GetBatteryMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pause     string
+		eco       string
+		discharge string
+		expected  inverter.BatteryMode
+	}{
+		{
+			name:      "eco",
+			pause:     "testdata/read_pause_battery_0_200.json",
+			eco:       "testdata/read_eco_mode_enabled_200.json",
+			discharge: "testdata/read_discharge_disabled_200.json",
+			expected:  inverter.BatteryModeEco,
+		},
+		{
+			name:      "timed demand",
+			pause:     "testdata/read_pause_battery_0_200.json",
+			eco:       "testdata/read_eco_mode_enabled_200.json",
+			discharge: "testdata/read_discharge_enabled_200.json",
+			expected:  inverter.BatteryModeTimedDemand,
+		},
+		{
+			name:      "timed export",
+			pause:     "testdata/read_pause_battery_0_200.json",
+			eco:       "testdata/read_eco_mode_disabled_200.json",
+			discharge: "testdata/read_discharge_enabled_200.json",
+			expected:  inverter.BatteryModeTimedExport,
+		},
+		{
+			name:     "paused",
+			pause:    "testdata/read_pause_battery_3_200.json",
+			expected: inverter.BatteryModePaused,
+		},
+		{
+			name:      "custom",
+			pause:     "testdata/read_pause_battery_0_200.json",
+			eco:       "testdata/read_eco_mode_disabled_200.json",
+			discharge: "testdata/read_discharge_disabled_200.json",
+			expected:  inverter.BatteryModeCustom,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockHTTPClient, _ := newRouterClient(t, batteryModeRoutes(
+				readRoute("inverter-1", 96, tt.pause),
+				readRoute("inverter-1", 24, tt.eco),
+				readRoute("inverter-1", 56, tt.discharge),
+			)...)
+
+			cl := inverter.NewClient(
+				testToken,
+				inverter.WithHTTPClient(mockHTTPClient),
+			)
+
+			mode, err := cl.GetBatteryMode(context.Background(), "inverter-1")
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestClient_GetBatteryMode_SettingsByName(t *testing.T) {
+	t.Parallel()
+
+	mockHTTPClient, _ := newRouterClient(
+		t,
+		&mockRoute{
+			URL:        fmt.Sprintf("%s/inverter/%s/settings", baseURL, "inverter-1"),
+			Path:       "testdata/list_settings_ac_200.json",
+			StatusCode: http.StatusOK,
+		},
+		readRoute("inverter-1", 312, "testdata/read_eco_mode_enabled_200.json"),
+	)
+
+	cl := inverter.NewClient(
+		testToken,
+		inverter.WithHTTPClient(mockHTTPClient),
+	)
+
+	mode, err := cl.GetBatteryMode(context.Background(), "inverter-1")
+	require.NoError(t, err)
+	require.Equal(t, inverter.BatteryModeEco, mode)
+}
+
+func TestClient_SetBatteryMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("timed export", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, batteryModeRoutes(
+			writeRoute("inverter-1", 24, "testdata/write_eco_mode_disabled_200.json", `{"value":false}`),
+			writeRoute("inverter-1", 56, "testdata/write_discharge_enabled_200.json", `{"value":true}`),
+			writeRoute("inverter-1", 96, "testdata/write_pause_battery_0_200.json", `{"value":0}`),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		err := cl.SetBatteryMode(context.Background(), "inverter-1", inverter.BatteryModeTimedExport)
+		require.NoError(t, err)
+		for _, id := range []int{24, 56, 96} {
+			require.Equal(t, 1, rrt.Calls(fmt.Sprintf("%s/inverter/inverter-1/settings/%d/write", baseURL, id)))
+		}
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, batteryModeRoutes(
+			writeRoute("inverter-1", 96, "testdata/write_pause_battery_3_200.json", `{"value":3}`),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		err := cl.SetBatteryMode(context.Background(), "inverter-1", inverter.BatteryModePaused)
+		require.NoError(t, err)
+		require.Equal(t, 1, rrt.Calls(fmt.Sprintf("%s/inverter/inverter-1/settings/96/write", baseURL)))
+	})
+
+	t.Run("paused without pause setting", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(t, scheduleRoutes("inverter-1")...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		err := cl.SetBatteryMode(context.Background(), "inverter-1", inverter.BatteryModePaused)
+		require.ErrorIs(t, err, inverter.ErrUnsupported)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		t.Parallel()
+
+		cl := inverter.NewClient(testToken)
+
+		err := cl.SetBatteryMode(context.Background(), "inverter-1", inverter.BatteryModeCustom)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,214 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// multiSlotFirmwareARM is the first ARM firmware of single phase inverters
+// exposing the ten slot charge and discharge schedules.
+const multiSlotFirmwareARM = 449
+
+const maxScheduleSlots = 10
+
+type InverterFamily string
+
+const (
+	InverterFamilyUnknown    InverterFamily = "unknown"
+	InverterFamilyHybrid     InverterFamily = "hybrid"
+	InverterFamilyACCoupled  InverterFamily = "ac_coupled"
+	InverterFamilyThreePhase InverterFamily = "three_phase"
+	InverterFamilyAllInOne   InverterFamily = "all_in_one"
+	InverterFamilyEMS        InverterFamily = "ems"
+	InverterFamilyGateway    InverterFamily = "gateway"
+)
+
+// ParseInverterFamily maps the model reported in InverterInfo.Model to its family.
+func ParseInverterFamily(model string) InverterFamily {
+	words := strings.FieldsFunc(strings.ToLower(model), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	switch {
+	case len(words) == 0:
+		return InverterFamilyUnknown
+	case hasWords(words, "3ph"), hasWords(words, "three", "phase"), hasWords(words, "3", "phase"):
+		return InverterFamilyThreePhase
+	case hasWords(words, "all", "in", "one"), hasWords(words, "aio"):
+		return InverterFamilyAllInOne
+	case hasWords(words, "gateway"):
+		return InverterFamilyGateway
+	case hasWords(words, "ems"):
+		return InverterFamilyEMS
+	case hasWords(words, "hybrid"):
+		return InverterFamilyHybrid
+	case hasWords(words, "ac"):
+		return InverterFamilyACCoupled
+	default:
+		return InverterFamilyUnknown
+	}
+}
+
+// hasWords reports whether phrase appears in words as consecutive words.
+func hasWords(words []string, phrase ...string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+type Capability int
+
+const (
+	CapabilityACCharge Capability = iota
+	CapabilityDCDischarge
+	CapabilityEcoMode
+	CapabilityEPS
+)
+
+func (c Capability) String() string {
+	switch c {
+	case CapabilityACCharge:
+		return "AC charge"
+	case CapabilityDCDischarge:
+		return "DC discharge"
+	case CapabilityEcoMode:
+		return "eco mode"
+	case CapabilityEPS:
+		return "EPS"
+	default:
+		return "unknown"
+	}
+}
+
+// Capabilities describes what an inverter can do, derived from its model and
+// firmware.
+type Capabilities struct {
+	Family           InverterFamily
+	Model            string
+	FirmwareARM      int
+	ThreePhase       bool
+	EPS              bool
+	ACCharge         bool
+	DCDischarge      bool
+	EcoMode          bool
+	ACChargeSlots    int
+	DCDischargeSlots int
+}
+
+func CapabilitiesFor(inv *DeviceInverter) *Capabilities {
+	caps := &Capabilities{Family: InverterFamilyUnknown}
+	if inv.Info != nil {
+		caps.Model = inv.Info.Model
+		caps.Family = ParseInverterFamily(inv.Info.Model)
+	}
+	if inv.FirmwareVersion != nil {
+		caps.FirmwareARM = inv.FirmwareVersion.ARM
+	}
+
+	slots := 1
+	if caps.FirmwareARM >= multiSlotFirmwareARM {
+		slots = maxScheduleSlots
+	}
+
+	switch caps.Family {
+	case InverterFamilyUnknown:
+		// Only what every battery inverter offers, until the model is known.
+		caps.ACCharge = true
+		caps.EcoMode = true
+		caps.ACChargeSlots = 1
+	case InverterFamilyHybrid:
+		caps.EPS = true
+		caps.ACCharge = true
+		caps.DCDischarge = true
+		caps.EcoMode = true
+		caps.ACChargeSlots = slots
+		caps.DCDischargeSlots = max(slots, 2)
+	case InverterFamilyACCoupled:
+		caps.EPS = true
+		caps.ACCharge = true
+		caps.EcoMode = true
+		caps.ACChargeSlots = slots
+	case InverterFamilyThreePhase:
+		caps.ThreePhase = true
+		caps.EPS = true
+		caps.ACCharge = true
+		caps.DCDischarge = true
+		caps.EcoMode = true
+		caps.ACChargeSlots = maxScheduleSlots
+		caps.DCDischargeSlots = maxScheduleSlots
+	case InverterFamilyAllInOne:
+		caps.EPS = true
+		caps.ACCharge = true
+		caps.DCDischarge = true
+		caps.EcoMode = true
+		caps.ACChargeSlots = maxScheduleSlots
+		caps.DCDischargeSlots = maxScheduleSlots
+	}
+
+	return caps
+}
+
+func (caps *Capabilities) Supports(c Capability) bool {
+	switch c {
+	case CapabilityACCharge:
+		return caps.ACCharge
+	case CapabilityDCDischarge:
+		return caps.DCDischarge
+	case CapabilityEcoMode:
+		return caps.EcoMode
+	case CapabilityEPS:
+		return caps.EPS
+	default:
+		return false
+	}
+}
+
+// Capabilities returns the capabilities of an inverter, looking it up in the
+// communication devices of the account the first time the serial number is seen.
+func (c *Client) Capabilities(ctx context.Context, serialNumber string) (*Capabilities, error) {
+	c.capabilitiesMu.Lock()
+	caps, ok := c.capabilities[serialNumber]
+	c.capabilitiesMu.Unlock()
+	if ok {
+		return caps, nil
+	}
+
+	for d, err := range c.AllCommunicationDevices(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if d.Inverter == nil || d.Inverter.Serial != serialNumber {
+			continue
+		}
+
+		caps = CapabilitiesFor(d.Inverter)
+		c.capabilitiesMu.Lock()
+		c.capabilities[serialNumber] = caps
+		c.capabilitiesMu.Unlock()
+		return caps, nil
+	}
+
+	return nil, fmt.Errorf("inverter %s: %w", serialNumber, ErrNotFound)
+}
+
+// requireCapability returns ErrUnsupported when the client is created
+// WithCapabilityChecks and the inverter lacks the capability.
+func (c *Client) requireCapability(ctx context.Context, serialNumber string, capability Capability) error {
+	if !c.checkCapabilities {
+		return nil
+	}
+
+	caps, err := c.Capabilities(ctx, serialNumber)
+	if err != nil {
+		return err
+	}
+	if !caps.Supports(capability) {
+		return fmt.Errorf("inverter %s (%s): %s: %w", serialNumber, caps.Model, capability, ErrUnsupported)
+	}
+	return nil
+}
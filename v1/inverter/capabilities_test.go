@@ -0,0 +1,162 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestParseInverterFamily(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		model    string
+		expected inverter.InverterFamily
+	}{
+		{model: "Hybrid", expected: inverter.InverterFamilyHybrid},
+		{model: "Gen 2 Hybrid", expected: inverter.InverterFamilyHybrid},
+		{model: "AC", expected: inverter.InverterFamilyACCoupled},
+		{model: "AC Coupled", expected: inverter.InverterFamilyACCoupled},
+		{model: "3PH Hybrid", expected: inverter.InverterFamilyThreePhase},
+		{model: "All in One", expected: inverter.InverterFamilyAllInOne},
+		{model: "EMS", expected: inverter.InverterFamilyEMS},
+		{model: "Plant EMS-2", expected: inverter.InverterFamilyEMS},
+		{model: "Storage Systems", expected: inverter.InverterFamilyUnknown},
+		{model: "All-in-One", expected: inverter.InverterFamilyAllInOne},
+		{model: "AIO", expected: inverter.InverterFamilyAllInOne},
+		{model: "Gen 3 AC", expected: inverter.InverterFamilyACCoupled},
+		{model: "Three Phase", expected: inverter.InverterFamilyThreePhase},
+		{model: "Radio Link", expected: inverter.InverterFamilyUnknown},
+		{model: "Acme Hybridge", expected: inverter.InverterFamilyUnknown},
+		{model: "Graph 3phx", expected: inverter.InverterFamilyUnknown},
+		{model: "Gateway", expected: inverter.InverterFamilyGateway},
+		{model: "Something Else", expected: inverter.InverterFamilyUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, inverter.ParseInverterFamily(tt.model))
+		})
+	}
+}
+
+func TestCapabilitiesFor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hybrid with multi slot firmware", func(t *testing.T) {
+		t.Parallel()
+
+		caps := inverter.CapabilitiesFor(&inverter.DeviceInverter{
+			Info:            &inverter.InverterInfo{Model: "Hybrid"},
+			FirmwareVersion: &inverter.InverterFirmware{ARM: 449},
+		})
+		require.True(t, caps.Supports(inverter.CapabilityDCDischarge))
+		require.True(t, caps.Supports(inverter.CapabilityEPS))
+		require.False(t, caps.ThreePhase)
+		require.Equal(t, 10, caps.ACChargeSlots)
+		require.Equal(t, 10, caps.DCDischargeSlots)
+	})
+
+	t.Run("hybrid with old firmware", func(t *testing.T) {
+		t.Parallel()
+
+		caps := inverter.CapabilitiesFor(&inverter.DeviceInverter{
+			Info:            &inverter.InverterInfo{Model: "Hybrid"},
+			FirmwareVersion: &inverter.InverterFirmware{ARM: 190},
+		})
+		require.Equal(t, 1, caps.ACChargeSlots)
+		require.Equal(t, 2, caps.DCDischargeSlots)
+	})
+
+	t.Run("ac coupled", func(t *testing.T) {
+		t.Parallel()
+
+		caps := inverter.CapabilitiesFor(&inverter.DeviceInverter{
+			Info: &inverter.InverterInfo{Model: "AC"},
+		})
+		require.True(t, caps.Supports(inverter.CapabilityACCharge))
+		require.False(t, caps.Supports(inverter.CapabilityDCDischarge))
+		require.Equal(t, 0, caps.DCDischargeSlots)
+	})
+
+	t.Run("three phase", func(t *testing.T) {
+		t.Parallel()
+
+		caps := inverter.CapabilitiesFor(&inverter.DeviceInverter{
+			Info: &inverter.InverterInfo{Model: "3PH Hybrid"},
+		})
+		require.True(t, caps.ThreePhase)
+		require.True(t, caps.Supports(inverter.CapabilityDCDischarge))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		caps := inverter.CapabilitiesFor(&inverter.DeviceInverter{
+			Info:            &inverter.InverterInfo{Model: "Something Else"},
+			FirmwareVersion: &inverter.InverterFirmware{ARM: 449},
+		})
+		require.True(t, caps.Supports(inverter.CapabilityACCharge))
+		require.True(t, caps.Supports(inverter.CapabilityEcoMode))
+		require.False(t, caps.Supports(inverter.CapabilityDCDischarge))
+		require.False(t, caps.Supports(inverter.CapabilityEPS))
+		require.Equal(t, 1, caps.ACChargeSlots)
+		require.Equal(t, 0, caps.DCDischargeSlots)
+	})
+
+	t.Run("gateway", func(t *testing.T) {
+		t.Parallel()
+
+		caps := inverter.CapabilitiesFor(&inverter.DeviceInverter{
+			Info: &inverter.InverterInfo{Model: "Gateway"},
+		})
+		require.False(t, caps.Supports(inverter.CapabilityACCharge))
+		require.False(t, caps.Supports(inverter.CapabilityEcoMode))
+	})
+}
+
+func TestClient_WithCapabilityChecks(t *testing.T) {
+	t.Parallel()
+
+	devicesURL := fmt.Sprintf("%s/communication-device?page=1", baseURL)
+	writeURL := fmt.Sprintf("%s/inverter/%s/settings/%s/write", baseURL, "AC2105C001", inverter.DefaultSettingDischargeStart)
+	readURL := fmt.Sprintf("%s/inverter/%s/settings/%s/read", baseURL, "AC2105C001", inverter.DefaultSettingChargeLimit)
+
+	mockHTTPClient, rrt := newRouterClient(
+		t,
+		&mockRoute{URL: devicesURL, Path: "testdata/communication_devices_ac_200.json", StatusCode: http.StatusOK},
+		&mockRoute{URL: writeURL, Path: "testdata/write_discharge_start_200.json", StatusCode: http.StatusOK},
+		&mockRoute{URL: readURL, Path: "testdata/read_charge_limit_200.json", StatusCode: http.StatusOK},
+	)
+
+	cl := inverter.NewClient(
+		testToken,
+		inverter.WithHTTPClient(mockHTTPClient),
+		inverter.WithCapabilityChecks(),
+	)
+
+	_, err := cl.WriteSettingDischargeStart(
+		context.Background(),
+		inverter.NewWriteSettingArgs("AC2105C001", inverter.DefaultSettingDischargeStart, inverter.MustParseTimeOfDay("16:00")),
+	)
+	require.ErrorIs(t, err, inverter.ErrUnsupported)
+	require.Equal(t, 0, rrt.Calls(writeURL))
+
+	data, err := cl.ReadSettingChargeLimit(
+		context.Background(),
+		inverter.NewReadSettingArgs("AC2105C001", inverter.DefaultSettingChargeLimit),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 100, data.Data.Value)
+	require.Equal(t, 1, rrt.Calls(devicesURL))
+
+	_, err = cl.Capabilities(context.Background(), "unknown-serial")
+	require.ErrorIs(t, err, inverter.ErrNotFound)
+}
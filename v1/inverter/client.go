@@ -5,8 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 )
 
@@ -29,9 +30,23 @@ const (
 )
 
 type Client struct {
-	baseURL string
-	token   string
-	httpCl  *http.Client
+	baseURL        string
+	token          string
+	httpCl         *http.Client
+	validateWrites bool
+	strictWrites   bool
+	retry          *RetryPolicy
+	limiter        *RateLimiter
+
+	checkCapabilities bool
+
+	catalogsMu sync.Mutex
+	catalogs   map[string]*SettingCatalog
+
+	capabilitiesMu sync.Mutex
+	capabilities   map[string]*Capabilities
+
+	snapshots SnapshotStore
 }
 
 func NewClient(token string, opts ...Option) *Client {
@@ -41,9 +56,19 @@ func NewClient(token string, opts ...Option) *Client {
 	}
 
 	return &Client{
-		token:   token,
-		baseURL: conf.baseURL,
-		httpCl:  conf.httpClient,
+		token:          token,
+		baseURL:        conf.baseURL,
+		httpCl:         conf.httpClient,
+		validateWrites: conf.validateWrites,
+		strictWrites:   conf.strictWrites,
+		retry:          conf.retry,
+		limiter:        conf.limiter,
+
+		checkCapabilities: conf.checkCapabilities,
+
+		catalogs:     make(map[string]*SettingCatalog),
+		capabilities: make(map[string]*Capabilities),
+		snapshots:    NewMemorySnapshotStore(),
 	}
 }
 
@@ -89,49 +114,60 @@ func NewReadSettingArgs(serialNumber string, settingID string) *ReadSettingArgs
 	}
 }
 
-type ReadSettingChargeStartResponse struct {
+type ReadSettingResponse[T any] struct {
 	Data struct {
-		Value string `json:"value"`
+		Value T `json:"value"`
 	} `json:"data"`
 }
 
-func (c *Client) ReadSettingChargeStart(
-	ctx context.Context,
-	args *ReadSettingArgs,
-) (*ReadSettingChargeStartResponse, error) {
+// ReadSetting reads any setting exposed by ListSettings, decoding its value as T.
+func ReadSetting[T any](ctx context.Context, c *Client, args *ReadSettingArgs) (*ReadSettingResponse[T], error) {
 	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	res := new(ReadSettingChargeStartResponse)
-	if err := c.do(req, res); err != nil {
+	res := new(ReadSettingResponse[T])
+	if err := c.do(markIdempotent(req), res); err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
 
-type WriteSettingChargeStartArgs struct {
+type WriteSettingArgs[T any] struct {
 	InverterSerialNumber string  `json:"-"`
 	SettingID            string  `json:"-"`
-	Value                string  `json:"value"`
+	Value                T       `json:"value"`
 	Context              *string `json:"context,omitempty"`
 }
 
-type WriteSettingChargeStartResponse struct {
+func NewWriteSettingArgs[T any](serialNumber string, settingID string, value T) *WriteSettingArgs[T] {
+	return &WriteSettingArgs[T]{
+		InverterSerialNumber: serialNumber,
+		SettingID:            settingID,
+		Value:                value,
+	}
+}
+
+type WriteSettingResponse[T any] struct {
 	Data struct {
-		Value   string `json:"value"`
+		Value   T      `json:"value"`
 		Success bool   `json:"success"`
 		Message string `json:"message"`
 	} `json:"data"`
 }
 
-func (c *Client) WriteSettingChargeStart(
-	ctx context.Context,
-	args *WriteSettingChargeStartArgs,
-) (*WriteSettingChargeStartResponse, error) {
+// WriteSetting writes any setting exposed by ListSettings. When the client is
+// created WithSettingValidation the value is checked against the setting
+// catalog before any write request is made, and WithStrictWrites turns an
+// unconfirmed write into a *WriteError.
+func WriteSetting[T any](ctx context.Context, c *Client, args *WriteSettingArgs[T]) (*WriteSettingResponse[T], error) {
+	if err := c.validateSetting(ctx, args.InverterSerialNumber, args.SettingID, args.Value); err != nil {
+		return nil, err
+	}
+
 	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
 
 	b, err := json.Marshal(args)
@@ -143,446 +179,245 @@ func (c *Client) WriteSettingChargeStart(
 		return nil, err
 	}
 
-	res := new(WriteSettingChargeStartResponse)
+	res := new(WriteSettingResponse[T])
 	if err := c.do(req, res); err != nil {
 		return nil, err
 	}
 
+	if c.strictWrites && !res.Data.Success {
+		return nil, &WriteError{
+			InverterSerialNumber: args.InverterSerialNumber,
+			SettingID:            args.SettingID,
+			Value:                args.Value,
+			Message:              res.Data.Message,
+		}
+	}
+
 	return res, nil
 }
 
-type ReadSettingChargeEndResponse struct {
-	Data struct {
-		Value string `json:"value"`
-	} `json:"data"`
-}
+type ReadSettingChargeStartResponse = ReadSettingResponse[TimeOfDay]
 
-func (c *Client) ReadSettingChargeEnd(
+func (c *Client) ReadSettingChargeStart(
 	ctx context.Context,
 	args *ReadSettingArgs,
-) (*ReadSettingChargeEndResponse, error) {
-	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
-	if err != nil {
+) (*ReadSettingChargeStartResponse, error) {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
 		return nil, err
 	}
 
-	res := new(ReadSettingChargeEndResponse)
-	if err := c.do(req, res); err != nil {
+	return ReadSetting[TimeOfDay](ctx, c, args)
+}
+
+type WriteSettingChargeStartArgs = WriteSettingArgs[TimeOfDay]
+
+type WriteSettingChargeStartResponse = WriteSettingResponse[TimeOfDay]
+
+func (c *Client) WriteSettingChargeStart(
+	ctx context.Context,
+	args *WriteSettingChargeStartArgs,
+) (*WriteSettingChargeStartResponse, error) {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
-type WriteSettingChargeEndArgs struct {
-	InverterSerialNumber string  `json:"-"`
-	SettingID            string  `json:"-"`
-	Value                string  `json:"value"`
-	Context              *string `json:"context,omitempty"`
-}
+type ReadSettingChargeEndResponse = ReadSettingResponse[TimeOfDay]
 
-type WriteSettingChargeEndResponse struct {
-	Data struct {
-		Value   string `json:"value"`
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-	} `json:"data"`
+func (c *Client) ReadSettingChargeEnd(
+	ctx context.Context,
+	args *ReadSettingArgs,
+) (*ReadSettingChargeEndResponse, error) {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
+		return nil, err
+	}
+
+	return ReadSetting[TimeOfDay](ctx, c, args)
 }
 
+type WriteSettingChargeEndArgs = WriteSettingArgs[TimeOfDay]
+
+type WriteSettingChargeEndResponse = WriteSettingResponse[TimeOfDay]
+
 func (c *Client) WriteSettingChargeEnd(
 	ctx context.Context,
 	args *WriteSettingChargeEndArgs,
 ) (*WriteSettingChargeEndResponse, error) {
-	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
-
-	b, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	res := new(WriteSettingChargeEndResponse)
-	if err := c.do(req, res); err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
-type ReadSettingChargeEnabledResponse struct {
-	Data struct {
-		Value bool `json:"value"`
-	} `json:"data"`
-}
+type ReadSettingChargeEnabledResponse = ReadSettingResponse[bool]
 
 func (c *Client) ReadSettingChargeEnabled(
 	ctx context.Context,
 	args *ReadSettingArgs,
 ) (*ReadSettingChargeEnabledResponse, error) {
-	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
-	if err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
 		return nil, err
 	}
 
-	res := new(ReadSettingChargeEnabledResponse)
-	if err := c.do(req, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return ReadSetting[bool](ctx, c, args)
 }
 
-type WriteSettingChargeEnabledArgs struct {
-	InverterSerialNumber string  `json:"-"`
-	SettingID            string  `json:"-"`
-	Value                bool    `json:"value"`
-	Context              *string `json:"context,omitempty"`
-}
+type WriteSettingChargeEnabledArgs = WriteSettingArgs[bool]
 
-type WriteSettingChargeEnabledResponse struct {
-	Data struct {
-		Value   bool   `json:"value"`
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-	} `json:"data"`
-}
+type WriteSettingChargeEnabledResponse = WriteSettingResponse[bool]
 
 func (c *Client) WriteSettingChargeEnabled(
 	ctx context.Context,
 	args *WriteSettingChargeEnabledArgs,
 ) (*WriteSettingChargeEnabledResponse, error) {
-	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
-
-	b, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	res := new(WriteSettingChargeEnabledResponse)
-	if err := c.do(req, res); err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
-type ReadSettingChargeLimitResponse struct {
-	Data struct {
-		Value int `json:"value"`
-	} `json:"data"`
-}
+type ReadSettingChargeLimitResponse = ReadSettingResponse[int]
 
 func (c *Client) ReadSettingChargeLimit(
 	ctx context.Context,
 	args *ReadSettingArgs,
 ) (*ReadSettingChargeLimitResponse, error) {
-	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res := new(ReadSettingChargeLimitResponse)
-	if err := c.do(req, res); err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return ReadSetting[int](ctx, c, args)
 }
 
-type WriteSettingChargeLimitArgs struct {
-	InverterSerialNumber string  `json:"-"`
-	SettingID            string  `json:"-"`
-	Value                int     `json:"value"`
-	Context              *string `json:"context,omitempty"`
-}
+type WriteSettingChargeLimitArgs = WriteSettingArgs[int]
 
-type WriteSettingChargeLimitResponse struct {
-	Data struct {
-		Value   int    `json:"value"`
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-	} `json:"data"`
-}
+type WriteSettingChargeLimitResponse = WriteSettingResponse[int]
 
 func (c *Client) WriteSettingChargeLimit(
 	ctx context.Context,
 	args *WriteSettingChargeLimitArgs,
 ) (*WriteSettingChargeLimitResponse, error) {
-	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
-
-	b, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	if err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
 		return nil, err
 	}
 
-	res := new(WriteSettingChargeLimitResponse)
-	if err := c.do(req, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
-type ReadSettingDischargeEnabledResponse struct {
-	Data struct {
-		Value bool `json:"value"`
-	} `json:"data"`
-}
+type ReadSettingDischargeEnabledResponse = ReadSettingResponse[bool]
 
 func (c *Client) ReadSettingDischargeEnabled(
 	ctx context.Context,
 	args *ReadSettingArgs,
 ) (*ReadSettingDischargeEnabledResponse, error) {
-	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res := new(ReadSettingDischargeEnabledResponse)
-	if err := c.do(req, res); err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityDCDischarge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return ReadSetting[bool](ctx, c, args)
 }
 
-type WriteSettingDischargeEnabledArgs struct {
-	InverterSerialNumber string  `json:"-"`
-	SettingID            string  `json:"-"`
-	Value                bool    `json:"value"`
-	Context              *string `json:"context,omitempty"`
-}
+type WriteSettingDischargeEnabledArgs = WriteSettingArgs[bool]
 
-type WriteSettingDischargeEnabledResponse struct {
-	Data struct {
-		Value   bool   `json:"value"`
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-	} `json:"data"`
-}
+type WriteSettingDischargeEnabledResponse = WriteSettingResponse[bool]
 
 func (c *Client) WriteSettingDischargeEnabled(
 	ctx context.Context,
 	args *WriteSettingDischargeEnabledArgs,
 ) (*WriteSettingDischargeEnabledResponse, error) {
-	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
-
-	b, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	res := new(WriteSettingDischargeEnabledResponse)
-	if err := c.do(req, res); err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityDCDischarge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
-type ReadSettingDischargeStartResponse struct {
-	Data struct {
-		Value string `json:"value"`
-	} `json:"data"`
-}
+type ReadSettingDischargeStartResponse = ReadSettingResponse[TimeOfDay]
 
 func (c *Client) ReadSettingDischargeStart(
 	ctx context.Context,
 	args *ReadSettingArgs,
 ) (*ReadSettingDischargeStartResponse, error) {
-	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res := new(ReadSettingDischargeStartResponse)
-	if err := c.do(req, res); err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityDCDischarge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return ReadSetting[TimeOfDay](ctx, c, args)
 }
 
-type WriteSettingDischargeStartArgs struct {
-	InverterSerialNumber string  `json:"-"`
-	SettingID            string  `json:"-"`
-	Value                string  `json:"value"`
-	Context              *string `json:"context,omitempty"`
-}
+type WriteSettingDischargeStartArgs = WriteSettingArgs[TimeOfDay]
 
-type WriteSettingDischargeStartResponse struct {
-	Data struct {
-		Value   string `json:"value"`
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-	} `json:"data"`
-}
+type WriteSettingDischargeStartResponse = WriteSettingResponse[TimeOfDay]
 
 func (c *Client) WriteSettingDischargeStart(
 	ctx context.Context,
 	args *WriteSettingDischargeStartArgs,
 ) (*WriteSettingDischargeStartResponse, error) {
-	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
-
-	b, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	res := new(WriteSettingDischargeStartResponse)
-	if err := c.do(req, res); err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityDCDischarge); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
-type ReadSettingDischargeEndResponse struct {
-	Data struct {
-		Value string `json:"value"`
-	} `json:"data"`
-}
+type ReadSettingDischargeEndResponse = ReadSettingResponse[TimeOfDay]
 
 func (c *Client) ReadSettingDischargeEnd(
 	ctx context.Context,
 	args *ReadSettingArgs,
 ) (*ReadSettingDischargeEndResponse, error) {
-	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
-	if err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityDCDischarge); err != nil {
 		return nil, err
 	}
 
-	res := new(ReadSettingDischargeEndResponse)
-	if err := c.do(req, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return ReadSetting[TimeOfDay](ctx, c, args)
 }
 
-type WriteSettingDischargeEndArgs struct {
-	InverterSerialNumber string  `json:"-"`
-	SettingID            string  `json:"-"`
-	Value                string  `json:"value"`
-	Context              *string `json:"context,omitempty"`
-}
+type WriteSettingDischargeEndArgs = WriteSettingArgs[TimeOfDay]
 
-type WriteSettingDischargeEndResponse struct {
-	Data struct {
-		Value   string `json:"value"`
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-	} `json:"data"`
-}
+type WriteSettingDischargeEndResponse = WriteSettingResponse[TimeOfDay]
 
 func (c *Client) WriteSettingDischargeEnd(
 	ctx context.Context,
 	args *WriteSettingDischargeEndArgs,
 ) (*WriteSettingDischargeEndResponse, error) {
-	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
-
-	b, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	if err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityDCDischarge); err != nil {
 		return nil, err
 	}
 
-	res := new(WriteSettingDischargeEndResponse)
-	if err := c.do(req, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
-type ReadSettingEcoModeEnabledResponse struct {
-	Data struct {
-		Value bool `json:"value"`
-	} `json:"data"`
-}
+type ReadSettingEcoModeEnabledResponse = ReadSettingResponse[bool]
 
 func (c *Client) ReadSettingEcoModeEnabled(
 	ctx context.Context,
 	args *ReadSettingArgs,
 ) (*ReadSettingEcoModeEnabledResponse, error) {
-	u := fmt.Sprintf(fmtSettingRead, c.baseURL, args.InverterSerialNumber, args.SettingID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
-	if err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityEcoMode); err != nil {
 		return nil, err
 	}
 
-	res := new(ReadSettingEcoModeEnabledResponse)
-	if err := c.do(req, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return ReadSetting[bool](ctx, c, args)
 }
 
-type WriteSettingEcoModeEnabledArgs struct {
-	InverterSerialNumber string  `json:"-"`
-	SettingID            string  `json:"-"`
-	Value                bool    `json:"value"`
-	Context              *string `json:"context,omitempty"`
-}
+type WriteSettingEcoModeEnabledArgs = WriteSettingArgs[bool]
 
-type WriteSettingEcoModeEnabledResponse struct {
-	Data struct {
-		Value   bool   `json:"value"`
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-	} `json:"data"`
-}
+type WriteSettingEcoModeEnabledResponse = WriteSettingResponse[bool]
 
 func (c *Client) WriteSettingEcoModeEnabled(
 	ctx context.Context,
 	args *WriteSettingEcoModeEnabledArgs,
 ) (*WriteSettingEcoModeEnabledResponse, error) {
-	u := fmt.Sprintf(fmtSettingWrite, c.baseURL, args.InverterSerialNumber, args.SettingID)
-
-	b, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	if err != nil {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityEcoMode); err != nil {
 		return nil, err
 	}
 
-	res := new(WriteSettingEcoModeEnabledResponse)
-	if err := c.do(req, res); err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return WriteSetting(ctx, c, args)
 }
 
 type SystemDataLatestArgs struct {
@@ -654,37 +489,58 @@ func (c *Client) SystemDataLatest(ctx context.Context, args *SystemDataLatestArg
 type EventsArgs struct {
 	InverterSerialNumber string
 	Page                 *int
+	PageSize             *int
+	// Start and End restrict events to those starting within the range.
+	Start *time.Time
+	End   *time.Time
+	// Types restricts events to the given event names, e.g. "Battery Voltage Low".
+	Types []string
+	// Cleared selects only cleared (true) or only ongoing (false) events.
+	Cleared *bool
+}
+
+func (a *EventsArgs) query() url.Values {
+	q := pageValues(a.Page, a.PageSize)
+	if a.Start != nil {
+		q.Set("start", a.Start.UTC().Format(time.RFC3339))
+	}
+	if a.End != nil {
+		q.Set("end", a.End.UTC().Format(time.RFC3339))
+	}
+	for _, t := range a.Types {
+		q.Add("types[]", t)
+	}
+	if a.Cleared != nil {
+		if *a.Cleared {
+			q.Set("cleared", "1")
+		} else {
+			q.Set("cleared", "0")
+		}
+	}
+	return q
 }
 
 type Event struct {
 	Event     string    `json:"event"`
 	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
+	// EndTime is nil while the event is ongoing.
+	EndTime *time.Time `json:"end_time"`
+}
+
+func (e *Event) IsOngoing() bool {
+	return e.EndTime == nil || e.EndTime.IsZero()
 }
 
 type EventsResponse struct {
-	Data  []*Event `json:"data"`
-	Links struct {
-		First string      `json:"first"`
-		Last  string      `json:"last"`
-		Prev  interface{} `json:"prev"`
-		Next  interface{} `json:"next"`
-	} `json:"links"`
-	Meta struct {
-		CurrentPage int    `json:"current_page"`
-		From        int    `json:"from"`
-		LastPage    int    `json:"last_page"`
-		Path        string `json:"path"`
-		PerPage     int    `json:"per_page"`
-		To          int    `json:"to"`
-		Total       int    `json:"total"`
-	} `json:"meta"`
+	Data  []*Event  `json:"data"`
+	Links PageLinks `json:"links"`
+	Meta  PageMeta  `json:"meta"`
 }
 
 func (c *Client) Events(ctx context.Context, args *EventsArgs) (*EventsResponse, error) {
 	u := fmt.Sprintf(fmtEvents, c.baseURL, args.InverterSerialNumber)
-	if args.Page != nil {
-		u = fmt.Sprintf("%s?page=%d", u, *args.Page)
+	if q := args.query(); len(q) > 0 {
+		u = u + "?" + q.Encode()
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
@@ -703,18 +559,14 @@ func (c *Client) Events(ctx context.Context, args *EventsArgs) (*EventsResponse,
 func (c *Client) do(req *http.Request, res any) error {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	resp, err := c.httpCl.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= 300 {
-		msg, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf("unexpected status code: %d body: %s", resp.StatusCode, msg)
+		return newAPIError(resp)
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
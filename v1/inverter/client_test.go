@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -64,6 +65,10 @@ func (mrt *MockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 	}, nil
 }
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func newMockClient(
 	t *testing.T,
 	path string,
@@ -98,6 +103,95 @@ func newMockClient(
 	}
 }
 
+// mockRoute is a canned response served by RouterRoundTripper
+type mockRoute struct {
+	URL              string
+	Path             string
+	StatusCode       int
+	Header           http.Header
+	ExpectedBodyJSON string
+}
+
+// RouterRoundTripper serves mock responses by URL, in the order they were registered
+type RouterRoundTripper struct {
+	t      *testing.T
+	mu     sync.Mutex
+	routes map[string][]*mockRoute
+	calls  map[string]int
+}
+
+// RoundTrip implements the RoundTripper interface
+func (rrt *RouterRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rrt.mu.Lock()
+	defer rrt.mu.Unlock()
+
+	u := req.URL.String()
+	routes := rrt.routes[u]
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("unexpected URL: %s", u)
+	}
+	route := routes[0]
+	if len(routes) > 1 {
+		rrt.routes[u] = routes[1:]
+	}
+	rrt.calls[u]++
+
+	if req.Header.Get("Authorization") != "Bearer "+testToken {
+		return nil, fmt.Errorf("expected Authorization: %s, got: %s", testToken, req.Header.Get("Authorization"))
+	}
+
+	if route.ExpectedBodyJSON != "" {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		require.JSONEq(rrt.t, route.ExpectedBodyJSON, string(b))
+	}
+
+	var b []byte
+	if route.Path != "" {
+		var err error
+		b, err = os.ReadFile(route.Path)
+		require.NoError(rrt.t, err)
+	}
+
+	header := make(http.Header)
+	for k, v := range route.Header {
+		header[k] = v
+	}
+	header.Set("Content-Type", "application/json")
+
+	return &http.Response{
+		StatusCode: route.StatusCode,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+		Header:     header,
+	}, nil
+}
+
+// Calls returns how many requests were made to u
+func (rrt *RouterRoundTripper) Calls(u string) int {
+	rrt.mu.Lock()
+	defer rrt.mu.Unlock()
+	return rrt.calls[u]
+}
+
+func newRouterClient(t *testing.T, routes ...*mockRoute) (*http.Client, *RouterRoundTripper) {
+	t.Helper()
+
+	rrt := &RouterRoundTripper{
+		t:      t,
+		routes: make(map[string][]*mockRoute),
+		calls:  make(map[string]int),
+	}
+	for _, route := range routes {
+		rrt.routes[route.URL] = append(rrt.routes[route.URL], route)
+	}
+
+	return &http.Client{
+		Transport: rrt,
+	}, rrt
+}
+
 func TestClient_ListSettings(t *testing.T) {
 	t.Parallel()
 
@@ -137,6 +231,76 @@ func TestClient_ListSettings(t *testing.T) {
 	})
 }
 
+func TestReadSetting(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		args := inverter.NewReadSettingArgs("inverter-1", "266")
+
+		testURL := fmt.Sprintf(
+			"%s/inverter/%s/settings/%s/read",
+			baseURL,
+			args.InverterSerialNumber,
+			args.SettingID,
+		)
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/read_charge_start_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := inverter.ReadSetting[string](context.Background(), cl, args)
+		require.NoError(t, err)
+		require.Equal(t, "01:00", data.Data.Value)
+	})
+}
+
+func TestWriteSetting(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		args := inverter.NewWriteSettingArgs("inverter-1", "77", 100)
+
+		testURL := fmt.Sprintf(
+			"%s/inverter/%s/settings/%s/write",
+			baseURL,
+			args.InverterSerialNumber,
+			args.SettingID,
+		)
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/write_charge_limit_200.json",
+			http.StatusOK,
+			testURL,
+			"{ \"value\":100 }",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := inverter.WriteSetting(context.Background(), cl, args)
+		require.NoError(t, err)
+		require.Equal(t, 100, data.Data.Value)
+		require.True(t, data.Data.Success)
+		require.Equal(t, "Written Successfully", data.Data.Message)
+	})
+}
+
 func TestClient_ReadSettingChargeStart(t *testing.T) {
 	t.Parallel()
 
@@ -172,9 +336,9 @@ func TestClient_ReadSettingChargeStart(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.ReadSettingChargeStartResponse{
 			Data: struct {
-				Value string `json:"value"`
+				Value inverter.TimeOfDay `json:"value"`
 			}{
-				Value: "01:00",
+				Value: inverter.MustParseTimeOfDay("01:00"),
 			},
 		}
 		require.Equal(t, expected, data)
@@ -190,7 +354,7 @@ func TestClient_WriteSettingChargeStart(t *testing.T) {
 		args := &inverter.WriteSettingChargeStartArgs{
 			InverterSerialNumber: "inverter-1",
 			SettingID:            inverter.DefaultSettingChargeEnd,
-			Value:                "16:00",
+			Value:                inverter.MustParseTimeOfDay("16:00"),
 		}
 
 		testURL := fmt.Sprintf(
@@ -217,11 +381,11 @@ func TestClient_WriteSettingChargeStart(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.WriteSettingChargeStartResponse{
 			Data: struct {
-				Value   string `json:"value"`
-				Success bool   `json:"success"`
-				Message string `json:"message"`
+				Value   inverter.TimeOfDay `json:"value"`
+				Success bool               `json:"success"`
+				Message string             `json:"message"`
 			}{
-				Value:   "16:00",
+				Value:   inverter.MustParseTimeOfDay("16:00"),
 				Success: true,
 				Message: "Written Successfully",
 			},
@@ -265,9 +429,9 @@ func TestClient_ReadSettingChargeEnd(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.ReadSettingChargeEndResponse{
 			Data: struct {
-				Value string `json:"value"`
+				Value inverter.TimeOfDay `json:"value"`
 			}{
-				Value: "01:00",
+				Value: inverter.MustParseTimeOfDay("01:00"),
 			},
 		}
 		require.Equal(t, expected, data)
@@ -283,7 +447,7 @@ func TestClient_WriteSettingChargeEnd(t *testing.T) {
 		args := &inverter.WriteSettingChargeEndArgs{
 			InverterSerialNumber: "inverter-1",
 			SettingID:            inverter.DefaultSettingChargeEnd,
-			Value:                "16:00",
+			Value:                inverter.MustParseTimeOfDay("16:00"),
 		}
 
 		testURL := fmt.Sprintf(
@@ -310,11 +474,11 @@ func TestClient_WriteSettingChargeEnd(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.WriteSettingChargeEndResponse{
 			Data: struct {
-				Value   string `json:"value"`
-				Success bool   `json:"success"`
-				Message string `json:"message"`
+				Value   inverter.TimeOfDay `json:"value"`
+				Success bool               `json:"success"`
+				Message string             `json:"message"`
 			}{
-				Value:   "16:00",
+				Value:   inverter.MustParseTimeOfDay("16:00"),
 				Success: true,
 				Message: "Written Successfully",
 			},
@@ -544,9 +708,9 @@ func TestClient_ReadSettingDischargeStart(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.ReadSettingDischargeStartResponse{
 			Data: struct {
-				Value string `json:"value"`
+				Value inverter.TimeOfDay `json:"value"`
 			}{
-				Value: "01:00",
+				Value: inverter.MustParseTimeOfDay("01:00"),
 			},
 		}
 		require.Equal(t, expected, data)
@@ -562,7 +726,7 @@ func TestClient_WriteSettingDischargeStart(t *testing.T) {
 		args := &inverter.WriteSettingDischargeStartArgs{
 			InverterSerialNumber: "inverter-1",
 			SettingID:            inverter.DefaultSettingDischargeStart,
-			Value:                "16:00",
+			Value:                inverter.MustParseTimeOfDay("16:00"),
 		}
 
 		testURL := fmt.Sprintf(
@@ -589,11 +753,11 @@ func TestClient_WriteSettingDischargeStart(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.WriteSettingDischargeStartResponse{
 			Data: struct {
-				Value   string `json:"value"`
-				Success bool   `json:"success"`
-				Message string `json:"message"`
+				Value   inverter.TimeOfDay `json:"value"`
+				Success bool               `json:"success"`
+				Message string             `json:"message"`
 			}{
-				Value:   "16:00",
+				Value:   inverter.MustParseTimeOfDay("16:00"),
 				Success: true,
 				Message: "Written Successfully",
 			},
@@ -637,9 +801,9 @@ func TestClient_ReadSettingDischargeEnd(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.ReadSettingDischargeEndResponse{
 			Data: struct {
-				Value string `json:"value"`
+				Value inverter.TimeOfDay `json:"value"`
 			}{
-				Value: "01:00",
+				Value: inverter.MustParseTimeOfDay("01:00"),
 			},
 		}
 		require.Equal(t, expected, data)
@@ -655,7 +819,7 @@ func TestClient_WriteSettingDischargeEnd(t *testing.T) {
 		args := &inverter.WriteSettingDischargeEndArgs{
 			InverterSerialNumber: "inverter-1",
 			SettingID:            inverter.DefaultSettingDischargeEnd,
-			Value:                "16:00",
+			Value:                inverter.MustParseTimeOfDay("16:00"),
 		}
 
 		testURL := fmt.Sprintf(
@@ -682,11 +846,11 @@ func TestClient_WriteSettingDischargeEnd(t *testing.T) {
 		require.NoError(t, err)
 		expected := &inverter.WriteSettingDischargeEndResponse{
 			Data: struct {
-				Value   string `json:"value"`
-				Success bool   `json:"success"`
-				Message string `json:"message"`
+				Value   inverter.TimeOfDay `json:"value"`
+				Success bool               `json:"success"`
+				Message string             `json:"message"`
 			}{
-				Value:   "16:00",
+				Value:   inverter.MustParseTimeOfDay("16:00"),
 				Success: true,
 				Message: "Written Successfully",
 			},
@@ -996,44 +1160,31 @@ func TestClient_Events(t *testing.T) {
 				{
 					Event:     "Battery Voltage Low",
 					StartTime: time.Date(2024, 10, 3, 11, 33, 7, 0, time.UTC),
-					EndTime:   time.Date(2024, 10, 3, 11, 55, 21, 0, time.UTC),
+					EndTime:   ptr(time.Date(2024, 10, 3, 11, 55, 21, 0, time.UTC)),
 				},
 				{
 					Event:     "BMS Communication Fail",
 					StartTime: time.Date(2024, 10, 3, 11, 33, 7, 0, time.UTC),
-					EndTime:   time.Date(2024, 10, 3, 11, 55, 21, 0, time.UTC),
+					EndTime:   ptr(time.Date(2024, 10, 3, 11, 55, 21, 0, time.UTC)),
 				},
 				{
 					Event:     "BMS Communication Fail",
 					StartTime: time.Date(2024, 9, 30, 16, 28, 31, 0, time.UTC),
-					EndTime:   time.Date(2024, 10, 3, 9, 54, 32, 0, time.UTC),
+					EndTime:   ptr(time.Date(2024, 10, 3, 9, 54, 32, 0, time.UTC)),
 				},
 				{
 					Event:     "Electricity Meter Com Fail",
 					StartTime: time.Date(2024, 9, 30, 16, 10, 30, 0, time.UTC),
-					EndTime:   time.Date(2024, 10, 3, 12, 1, 6, 0, time.UTC),
+					EndTime:   ptr(time.Date(2024, 10, 3, 12, 1, 6, 0, time.UTC)),
 				},
 			},
-			Links: struct {
-				First string      `json:"first"`
-				Last  string      `json:"last"`
-				Prev  interface{} `json:"prev"`
-				Next  interface{} `json:"next"`
-			}{
+			Links: inverter.PageLinks{
 				First: "https://api.givenergy.cloud/v1/inverter/CE2234G437/events?page=1",
 				Last:  "https://api.givenergy.cloud/v1/inverter/CE2234G437/events?page=1",
 				Prev:  nil,
 				Next:  nil,
 			},
-			Meta: struct {
-				CurrentPage int    `json:"current_page"`
-				From        int    `json:"from"`
-				LastPage    int    `json:"last_page"`
-				Path        string `json:"path"`
-				PerPage     int    `json:"per_page"`
-				To          int    `json:"to"`
-				Total       int    `json:"total"`
-			}{
+			Meta: inverter.PageMeta{
 				CurrentPage: 1,
 				From:        1,
 				LastPage:    1,
@@ -1046,3 +1197,50 @@ func TestClient_Events(t *testing.T) {
 		require.Equal(t, expected, data)
 	})
 }
+
+func TestClient_EventsWithFilters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		page := 2
+		pageSize := 50
+		start := time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC)
+		cleared := false
+
+		args := &inverter.EventsArgs{
+			InverterSerialNumber: "inverter-1",
+			Page:                 &page,
+			PageSize:             &pageSize,
+			Start:                &start,
+			End:                  &end,
+			Types:                []string{"Battery Voltage Low", "BMS Communication Fail"},
+			Cleared:              &cleared,
+		}
+
+		testURL := fmt.Sprintf(
+			"%s/inverter/%s/events?cleared=0&end=2024-10-03T00%%3A00%%3A00Z&page=2&pageSize=50"+
+				"&start=2024-10-02T00%%3A00%%3A00Z&types%%5B%%5D=Battery+Voltage+Low&types%%5B%%5D=BMS+Communication+Fail",
+			baseURL,
+			args.InverterSerialNumber,
+		)
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/events_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.Events(context.Background(), args)
+		require.NoError(t, err)
+		require.Len(t, data.Data, 4)
+	})
+}
@@ -0,0 +1,145 @@
+package inverter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const fmtCommand = "%s/inverter/%s/commands/%s"
+
+// Command is the path segment of an inverter quick command.
+type Command string
+
+const (
+	CommandPauseBattery    Command = "pause-battery"
+	CommandSetChargeTarget Command = "set-charge-target"
+	CommandInstantCharge   Command = "instant-charge"
+	CommandInstantExport   Command = "instant-export"
+)
+
+type CommandResponse struct {
+	Data struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+// SendCommand sends any quick command with args as the JSON body. With
+// WithStrictWrites an unconfirmed command is turned into an *UnconfirmedError.
+func (c *Client) SendCommand(
+	ctx context.Context,
+	serialNumber string,
+	command Command,
+	args any,
+) (*CommandResponse, error) {
+	u := fmt.Sprintf(fmtCommand, c.baseURL, serialNumber, command)
+	return c.postOperation(ctx, u, serialNumber, "command "+string(command), args)
+}
+
+// postOperation posts body to u, turning an unconfirmed operation into an
+// *UnconfirmedError for strict clients.
+func (c *Client) postOperation(
+	ctx context.Context,
+	u string,
+	serialNumber string,
+	operation string,
+	body any,
+) (*CommandResponse, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(CommandResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	if c.strictWrites && !res.Data.Success {
+		return nil, &UnconfirmedError{
+			InverterSerialNumber: serialNumber,
+			Operation:            operation,
+			Message:              res.Data.Message,
+		}
+	}
+
+	return res, nil
+}
+
+type PauseBatteryArgs struct {
+	InverterSerialNumber string       `json:"-"`
+	Pause                BatteryPause `json:"value"`
+}
+
+type PauseBatteryResponse = CommandResponse
+
+func (c *Client) PauseBattery(ctx context.Context, args *PauseBatteryArgs) (*PauseBatteryResponse, error) {
+	if args.Pause < BatteryPauseNone || args.Pause > BatteryPauseChargeAndDischarge {
+		return nil, fmt.Errorf("invalid battery pause %d", args.Pause)
+	}
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
+		return nil, err
+	}
+
+	return c.SendCommand(ctx, args.InverterSerialNumber, CommandPauseBattery, args)
+}
+
+type SetChargeTargetArgs struct {
+	InverterSerialNumber string `json:"-"`
+	// TargetSOC is the battery percent at which charging stops.
+	TargetSOC int `json:"target_soc"`
+}
+
+type SetChargeTargetResponse = CommandResponse
+
+func (c *Client) SetChargeTarget(ctx context.Context, args *SetChargeTargetArgs) (*SetChargeTargetResponse, error) {
+	if args.TargetSOC < 0 || args.TargetSOC > 100 {
+		return nil, fmt.Errorf("invalid charge target %d: must be between 0 and 100", args.TargetSOC)
+	}
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
+		return nil, err
+	}
+
+	return c.SendCommand(ctx, args.InverterSerialNumber, CommandSetChargeTarget, args)
+}
+
+type InstantChargeArgs struct {
+	InverterSerialNumber string `json:"-"`
+	Enabled              bool   `json:"enabled"`
+}
+
+type InstantChargeResponse = CommandResponse
+
+// InstantCharge starts or stops charging the battery from the grid at full
+// power, regardless of the charge slots.
+func (c *Client) InstantCharge(ctx context.Context, args *InstantChargeArgs) (*InstantChargeResponse, error) {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityACCharge); err != nil {
+		return nil, err
+	}
+
+	return c.SendCommand(ctx, args.InverterSerialNumber, CommandInstantCharge, args)
+}
+
+type InstantExportArgs struct {
+	InverterSerialNumber string `json:"-"`
+	Enabled              bool   `json:"enabled"`
+}
+
+type InstantExportResponse = CommandResponse
+
+// InstantExport starts or stops discharging the battery to the grid at full
+// power, regardless of the discharge slots.
+func (c *Client) InstantExport(ctx context.Context, args *InstantExportArgs) (*InstantExportResponse, error) {
+	if err := c.requireCapability(ctx, args.InverterSerialNumber, CapabilityDCDischarge); err != nil {
+		return nil, err
+	}
+
+	return c.SendCommand(ctx, args.InverterSerialNumber, CommandInstantExport, args)
+}
@@ -0,0 +1,186 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func commandURL(serial string, command inverter.Command) string {
+	return fmt.Sprintf("%s/inverter/%s/commands/%s", baseURL, serial, command)
+}
+
+func TestClient_PauseBattery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/command_200.json",
+			http.StatusOK,
+			commandURL("inverter-1", inverter.CommandPauseBattery),
+			`{"value":3}`,
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.PauseBattery(context.Background(), &inverter.PauseBatteryArgs{
+			InverterSerialNumber: "inverter-1",
+			Pause:                inverter.BatteryPauseChargeAndDischarge,
+		})
+		require.NoError(t, err)
+		require.True(t, data.Data.Success)
+		require.Equal(t, "Command sent successfully", data.Data.Message)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, &mockRoute{
+			URL:        fmt.Sprintf("%s/communication-device?page=1", baseURL),
+			Path:       "testdata/communication_devices_gateway_200.json",
+			StatusCode: http.StatusOK,
+		})
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithCapabilityChecks(),
+		)
+
+		_, err := cl.PauseBattery(context.Background(), &inverter.PauseBatteryArgs{
+			InverterSerialNumber: "GW2301G001",
+			Pause:                inverter.BatteryPauseCharge,
+		})
+		require.ErrorIs(t, err, inverter.ErrUnsupported)
+		require.Equal(t, 0, rrt.Calls(commandURL("GW2301G001", inverter.CommandPauseBattery)))
+	})
+}
+
+func TestClient_SetChargeTarget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/command_200.json",
+			http.StatusOK,
+			commandURL("inverter-1", inverter.CommandSetChargeTarget),
+			`{"target_soc":80}`,
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.SetChargeTarget(context.Background(), &inverter.SetChargeTargetArgs{
+			InverterSerialNumber: "inverter-1",
+			TargetSOC:            80,
+		})
+		require.NoError(t, err)
+		require.True(t, data.Data.Success)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		t.Parallel()
+
+		cl := inverter.NewClient(testToken)
+
+		_, err := cl.SetChargeTarget(context.Background(), &inverter.SetChargeTargetArgs{
+			InverterSerialNumber: "inverter-1",
+			TargetSOC:            120,
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestClient_InstantExport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/command_200.json",
+			http.StatusOK,
+			commandURL("inverter-1", inverter.CommandInstantExport),
+			`{"enabled":true}`,
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.InstantExport(context.Background(), &inverter.InstantExportArgs{
+			InverterSerialNumber: "inverter-1",
+			Enabled:              true,
+		})
+		require.NoError(t, err)
+		require.True(t, data.Data.Success)
+	})
+
+	t.Run("strict not confirmed", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/command_failed_200.json",
+			http.StatusOK,
+			commandURL("inverter-1", inverter.CommandInstantExport),
+			`{"enabled":true}`,
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithStrictWrites(),
+		)
+
+		_, err := cl.InstantExport(context.Background(), &inverter.InstantExportArgs{
+			InverterSerialNumber: "inverter-1",
+			Enabled:              true,
+		})
+		var unconfirmedErr *inverter.UnconfirmedError
+		require.ErrorAs(t, err, &unconfirmedErr)
+		require.ErrorIs(t, err, inverter.ErrWriteRejected)
+		require.Equal(t, "command instant-export", unconfirmedErr.Operation)
+		require.Equal(t, "Inverter did not respond", unconfirmedErr.Message)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(t, &mockRoute{
+			URL:        fmt.Sprintf("%s/communication-device?page=1", baseURL),
+			Path:       "testdata/communication_devices_ac_200.json",
+			StatusCode: http.StatusOK,
+		})
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithCapabilityChecks(),
+		)
+
+		_, err := cl.InstantExport(context.Background(), &inverter.InstantExportArgs{
+			InverterSerialNumber: "AC2105C001",
+			Enabled:              true,
+		})
+		require.ErrorIs(t, err, inverter.ErrUnsupported)
+	})
+}
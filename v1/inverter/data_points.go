@@ -0,0 +1,113 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	fmtDataPoints = "%s/inverter/%s/data-points/%s"
+
+	dataPointsDateLayout = "2006-01-02"
+)
+
+type DataPointsArgs struct {
+	InverterSerialNumber string
+	Date                 time.Time
+	Page                 *int
+	PageSize             *int
+}
+
+type DataPointConsumption struct {
+	Power int `json:"power"`
+}
+
+type DataPointPower struct {
+	Solar       *SystemDataSolar      `json:"solar"`
+	Grid        *SystemDataGrid       `json:"grid"`
+	Battery     *SystemDataBattery    `json:"battery"`
+	Consumption *DataPointConsumption `json:"consumption"`
+	Inverter    *SystemDataInverter   `json:"inverter"`
+}
+
+type DataPoint struct {
+	Time   time.Time        `json:"time"`
+	Status string           `json:"status"`
+	Power  *DataPointPower  `json:"power"`
+	Today  *MeterDataEnergy `json:"today"`
+	Total  *MeterDataEnergy `json:"total"`
+}
+
+// SystemData returns the sample in the same shape as SystemDataLatest.
+func (d *DataPoint) SystemData() *SystemData {
+	sd := &SystemData{
+		Time:   d.Time,
+		Status: d.Status,
+	}
+	if d.Power == nil {
+		return sd
+	}
+
+	sd.Solar = d.Power.Solar
+	sd.Grid = d.Power.Grid
+	sd.Battery = d.Power.Battery
+	sd.Inverter = d.Power.Inverter
+	if d.Power.Consumption != nil {
+		sd.Consumption = d.Power.Consumption.Power
+	}
+	return sd
+}
+
+type DataPointsResponse struct {
+	Data  []*DataPoint `json:"data"`
+	Links PageLinks    `json:"links"`
+	Meta  PageMeta     `json:"meta"`
+}
+
+func (c *Client) DataPoints(ctx context.Context, args *DataPointsArgs) (*DataPointsResponse, error) {
+	u := fmt.Sprintf(
+		fmtDataPoints,
+		c.baseURL,
+		args.InverterSerialNumber,
+		args.Date.Format(dataPointsDateLayout),
+	) + pageQuery(args.Page, args.PageSize)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(DataPointsResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AllDataPoints walks every page of DataPoints for args.Date, starting at
+// args.Page (or the first page), and returns the samples in API order.
+func (c *Client) AllDataPoints(ctx context.Context, args *DataPointsArgs) ([]*DataPoint, error) {
+	pageArgs := *args
+	page := 1
+	if args.Page != nil {
+		page = *args.Page
+	}
+
+	var points []*DataPoint
+	for {
+		pageArgs.Page = &page
+		res, err := c.DataPoints(ctx, &pageArgs)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, res.Data...)
+		if len(res.Data) == 0 || !res.Meta.HasNext() {
+			return points, nil
+		}
+		page = res.Meta.CurrentPage + 1
+	}
+}
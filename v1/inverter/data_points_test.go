@@ -0,0 +1,130 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestClient_DataPoints(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		page := 1
+		pageSize := 1
+
+		args := &inverter.DataPointsArgs{
+			InverterSerialNumber: "inverter-1",
+			Date:                 time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC),
+			Page:                 &page,
+			PageSize:             &pageSize,
+		}
+
+		testURL := fmt.Sprintf(
+			"%s/inverter/%s/data-points/2024-10-17?page=1&pageSize=1",
+			baseURL,
+			args.InverterSerialNumber,
+		)
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/data_points_page_1_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.DataPoints(context.Background(), args)
+		require.NoError(t, err)
+		require.Len(t, data.Data, 1)
+		require.True(t, data.Meta.HasNext())
+		require.NotNil(t, data.Links.Next)
+
+		expected := &inverter.SystemData{
+			Time:   time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC),
+			Status: "Normal",
+			Solar: &inverter.SystemDataSolar{
+				Power: 0,
+				Arrays: []*inverter.DataSolar{
+					{
+						Array: 1,
+					},
+				},
+			},
+			Grid: &inverter.SystemDataGrid{
+				Voltage:   242.1,
+				Current:   1.8,
+				Power:     322,
+				Frequency: 50.01,
+			},
+			Battery: &inverter.SystemDataBattery{
+				Percent:     40,
+				Power:       0,
+				Temperature: 18,
+			},
+			Inverter: &inverter.SystemDataInverter{
+				Temperature:     26.8,
+				Power:           0,
+				OutputVoltage:   242.1,
+				OutputFrequency: 50.01,
+				EpsPower:        0,
+			},
+			Consumption: 322,
+		}
+		require.Equal(t, expected, data.Data[0].SystemData())
+		require.Equal(t, 0.3, data.Data[0].Today.Grid.Import)
+		require.Equal(t, 6521.3, data.Data[0].Total.Consumption)
+	})
+}
+
+func TestClient_AllDataPoints(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		args := &inverter.DataPointsArgs{
+			InverterSerialNumber: "inverter-1",
+			Date:                 time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC),
+		}
+
+		testURL := fmt.Sprintf("%s/inverter/%s/data-points/2024-10-17", baseURL, args.InverterSerialNumber)
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        testURL + "?page=1",
+				Path:       "testdata/data_points_page_1_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:        testURL + "?page=2",
+				Path:       "testdata/data_points_page_2_200.json",
+				StatusCode: http.StatusOK,
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.AllDataPoints(context.Background(), args)
+		require.NoError(t, err)
+		require.Len(t, data, 2)
+		require.Equal(t, time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC), data[0].Time)
+		require.Equal(t, time.Date(2024, 10, 17, 0, 5, 0, 0, time.UTC), data[1].Time)
+		require.Nil(t, args.Page)
+	})
+}
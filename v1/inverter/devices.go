@@ -0,0 +1,258 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"time"
+)
+
+const (
+	fmtCommunicationDevices = "%s/communication-device"
+	fmtCommunicationDevice  = "%s/communication-device/%s"
+	fmtAccount              = "%s/account"
+	fmtAccountDevices       = "%s/account/%d/devices"
+)
+
+type InverterBattery struct {
+	NominalCapacity float64 `json:"nominal_capacity"`
+	NominalVoltage  float64 `json:"nominal_voltage"`
+}
+
+type InverterInfo struct {
+	BatteryType   string           `json:"battery_type"`
+	Battery       *InverterBattery `json:"battery"`
+	Model         string           `json:"model"`
+	MaxChargeRate int              `json:"max_charge_rate"`
+}
+
+type InverterWarranty struct {
+	Type       string    `json:"type"`
+	ExpiryDate time.Time `json:"expiry_date"`
+}
+
+type InverterFirmware struct {
+	ARM int `json:"ARM"`
+	DSP int `json:"DSP"`
+}
+
+type BatteryModuleCapacity struct {
+	Full   float64 `json:"full"`
+	Design float64 `json:"design"`
+}
+
+type BatteryModule struct {
+	ModuleNumber    int                    `json:"module_number"`
+	Serial          string                 `json:"serial"`
+	FirmwareVersion string                 `json:"firmware_version"`
+	Capacity        *BatteryModuleCapacity `json:"capacity"`
+	CellCount       int                    `json:"cell_count"`
+	HasUSB          bool                   `json:"has_usb"`
+	NominalVoltage  float64                `json:"nominal_voltage"`
+}
+
+type InverterConnections struct {
+	Batteries []*BatteryModule `json:"batteries"`
+}
+
+type DeviceInverter struct {
+	Serial          string               `json:"serial"`
+	Status          string               `json:"status"`
+	LastOnline      time.Time            `json:"last_online"`
+	LastUpdated     time.Time            `json:"last_updated"`
+	CommissionDate  time.Time            `json:"commission_date"`
+	Info            *InverterInfo        `json:"info"`
+	Warranty        *InverterWarranty    `json:"warranty"`
+	FirmwareVersion *InverterFirmware    `json:"firmware_version"`
+	Connections     *InverterConnections `json:"connections"`
+	Flags           []string             `json:"flags"`
+}
+
+// CommunicationDevice is a dongle and the inverter it is attached to.
+type CommunicationDevice struct {
+	SerialNumber   string          `json:"serial_number"`
+	Type           string          `json:"type"`
+	CommissionDate time.Time       `json:"commission_date"`
+	Inverter       *DeviceInverter `json:"inverter"`
+}
+
+type CommunicationDevicesArgs struct {
+	Page     *int
+	PageSize *int
+}
+
+type CommunicationDevicesResponse struct {
+	Data  []*CommunicationDevice `json:"data"`
+	Links PageLinks              `json:"links"`
+	Meta  PageMeta               `json:"meta"`
+}
+
+// CommunicationDevices lists the devices the API token has access to.
+func (c *Client) CommunicationDevices(
+	ctx context.Context,
+	args *CommunicationDevicesArgs,
+) (*CommunicationDevicesResponse, error) {
+	u := fmt.Sprintf(fmtCommunicationDevices, c.baseURL) + pageQuery(args.Page, args.PageSize)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(CommunicationDevicesResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type CommunicationDeviceArgs struct {
+	SerialNumber string
+}
+
+type CommunicationDeviceResponse struct {
+	Data *CommunicationDevice `json:"data"`
+}
+
+func (c *Client) CommunicationDevice(
+	ctx context.Context,
+	args *CommunicationDeviceArgs,
+) (*CommunicationDeviceResponse, error) {
+	u := fmt.Sprintf(fmtCommunicationDevice, c.baseURL, args.SerialNumber)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(CommunicationDeviceResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AllCommunicationDevices returns an iterator over the devices of every page.
+func (c *Client) AllCommunicationDevices(ctx context.Context) iter.Seq2[*CommunicationDevice, error] {
+	return devicePages(ctx, func(page int) (*CommunicationDevicesResponse, error) {
+		return c.CommunicationDevices(ctx, &CommunicationDevicesArgs{Page: &page})
+	})
+}
+
+type Account struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	Email string `json:"email"`
+}
+
+type AccountResponse struct {
+	Data *Account `json:"data"`
+}
+
+// Account returns the account the API token belongs to.
+func (c *Client) Account(ctx context.Context) (*AccountResponse, error) {
+	u := fmt.Sprintf(fmtAccount, c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(AccountResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type AccountDevicesArgs struct {
+	AccountID int
+	Page      *int
+	PageSize  *int
+}
+
+type AccountDevicesResponse = CommunicationDevicesResponse
+
+// AccountDevices lists the devices of an account, which for installer and
+// distributor tokens includes the devices of their child accounts.
+func (c *Client) AccountDevices(ctx context.Context, args *AccountDevicesArgs) (*AccountDevicesResponse, error) {
+	u := fmt.Sprintf(fmtAccountDevices, c.baseURL, args.AccountID) + pageQuery(args.Page, args.PageSize)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(AccountDevicesResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AllAccountDevices returns an iterator over the devices of every page.
+func (c *Client) AllAccountDevices(ctx context.Context, accountID int) iter.Seq2[*CommunicationDevice, error] {
+	return devicePages(ctx, func(page int) (*AccountDevicesResponse, error) {
+		return c.AccountDevices(ctx, &AccountDevicesArgs{AccountID: accountID, Page: &page})
+	})
+}
+
+func devicePages(
+	ctx context.Context,
+	fetch func(page int) (*CommunicationDevicesResponse, error),
+) iter.Seq2[*CommunicationDevice, error] {
+	return func(yield func(*CommunicationDevice, error) bool) {
+		page := 1
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			res, err := fetch(page)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, d := range res.Data {
+				if !yield(d, nil) {
+					return
+				}
+			}
+			if len(res.Data) == 0 || !res.Meta.HasNext() {
+				return
+			}
+			page = res.Meta.CurrentPage + 1
+		}
+	}
+}
+
+// InverterSerialNumbers returns the serial numbers of every inverter the API
+// token has access to.
+func (c *Client) InverterSerialNumbers(ctx context.Context) ([]string, error) {
+	var serials []string
+	seen := make(map[string]bool)
+	for d, err := range c.AllCommunicationDevices(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if d.Inverter == nil || d.Inverter.Serial == "" || seen[d.Inverter.Serial] {
+			continue
+		}
+		seen[d.Inverter.Serial] = true
+		serials = append(serials, d.Inverter.Serial)
+	}
+	return serials, nil
+}
+
+// NewFleetFromAccount builds a Fleet of every inverter the API token has
+// access to.
+func NewFleetFromAccount(ctx context.Context, c *Client, opts ...FleetOption) (*Fleet, error) {
+	serials, err := c.InverterSerialNumbers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewFleet(c, serials, opts...), nil
+}
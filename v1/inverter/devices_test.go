@@ -0,0 +1,191 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func newDevicesClient(t *testing.T) *inverter.Client {
+	t.Helper()
+
+	testURL := fmt.Sprintf("%s/communication-device", baseURL)
+	mockHTTPClient, _ := newRouterClient(
+		t,
+		&mockRoute{URL: testURL + "?page=1", Path: "testdata/communication_devices_page_1_200.json", StatusCode: http.StatusOK},
+		&mockRoute{URL: testURL + "?page=2", Path: "testdata/communication_devices_page_2_200.json", StatusCode: http.StatusOK},
+	)
+
+	return inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient))
+}
+
+func TestClient_CommunicationDevice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		args := &inverter.CommunicationDeviceArgs{
+			SerialNumber: "WF2234G437",
+		}
+
+		testURL := fmt.Sprintf("%s/communication-device/%s", baseURL, args.SerialNumber)
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/communication_device_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.CommunicationDevice(context.Background(), args)
+		require.NoError(t, err)
+		commissioned := time.Date(2022, 8, 20, 0, 0, 0, 0, time.UTC)
+		lastOnline := time.Date(2024, 10, 17, 15, 22, 3, 0, time.UTC)
+		expected := &inverter.CommunicationDeviceResponse{
+			Data: &inverter.CommunicationDevice{
+				SerialNumber:   "WF2234G437",
+				Type:           "WIFI",
+				CommissionDate: commissioned,
+				Inverter: &inverter.DeviceInverter{
+					Serial:         "CE2234G437",
+					Status:         "NORMAL",
+					LastOnline:     lastOnline,
+					LastUpdated:    lastOnline,
+					CommissionDate: commissioned,
+					Info: &inverter.InverterInfo{
+						BatteryType: "LITHIUM",
+						Battery: &inverter.InverterBattery{
+							NominalCapacity: 186,
+							NominalVoltage:  51.2,
+						},
+						Model:         "Hybrid",
+						MaxChargeRate: 2600,
+					},
+					Warranty: &inverter.InverterWarranty{
+						Type:       "Standard",
+						ExpiryDate: time.Date(2034, 8, 20, 0, 0, 0, 0, time.UTC),
+					},
+					FirmwareVersion: &inverter.InverterFirmware{
+						ARM: 449,
+						DSP: 449,
+					},
+					Connections: &inverter.InverterConnections{
+						Batteries: []*inverter.BatteryModule{
+							{
+								ModuleNumber:    1,
+								Serial:          "BG2234G001",
+								FirmwareVersion: "3015",
+								Capacity: &inverter.BatteryModuleCapacity{
+									Full:   180.5,
+									Design: 186,
+								},
+								CellCount:      16,
+								HasUSB:         true,
+								NominalVoltage: 51.2,
+							},
+						},
+					},
+					Flags: []string{},
+				},
+			},
+		}
+		require.Equal(t, expected, data)
+	})
+}
+
+func TestClient_AllCommunicationDevices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var dongles []string
+		for d, err := range newDevicesClient(t).AllCommunicationDevices(context.Background()) {
+			require.NoError(t, err)
+			dongles = append(dongles, d.SerialNumber)
+		}
+		require.Equal(t, []string{"WF2234G437", "WF2301A001"}, dongles)
+	})
+}
+
+func TestNewFleetFromAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		fleet, err := inverter.NewFleetFromAccount(context.Background(), newDevicesClient(t))
+		require.NoError(t, err)
+		require.Equal(t, []string{"CE2234G437", "FA2301A001"}, fleet.SerialNumbers())
+	})
+}
+
+func TestClient_Account(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/account_200.json",
+			http.StatusOK,
+			fmt.Sprintf("%s/account", baseURL),
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.Account(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, &inverter.Account{
+			ID:    1234,
+			Name:  "jdoe",
+			Role:  "END_USER",
+			Email: "jdoe@example.com",
+		}, data.Data)
+	})
+}
+
+func TestClient_AllAccountDevices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		testURL := fmt.Sprintf("%s/account/%d/devices", baseURL, 1234)
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{URL: testURL + "?page=1", Path: "testdata/communication_devices_page_1_200.json", StatusCode: http.StatusOK},
+			&mockRoute{URL: testURL + "?page=2", Path: "testdata/communication_devices_page_2_200.json", StatusCode: http.StatusOK},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		var dongles []string
+		for d, err := range cl.AllAccountDevices(context.Background(), 1234) {
+			require.NoError(t, err)
+			dongles = append(dongles, d.SerialNumber)
+		}
+		require.Equal(t, []string{"WF2234G437", "WF2301A001"}, dongles)
+		require.Equal(t, 1, rrt.Calls(testURL+"?page=2"))
+	})
+}
@@ -0,0 +1,207 @@
+package inverter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	fmtEnergyFlows = "%s/inverter/%s/energy-flows"
+
+	energyFlowsTimeLayout = "2006-01-02 15:04"
+)
+
+type EnergyFlowGrouping int
+
+const (
+	EnergyFlowGroupingHalfHourly EnergyFlowGrouping = iota
+	EnergyFlowGroupingDaily
+	EnergyFlowGroupingMonthly
+	EnergyFlowGroupingYearly
+	EnergyFlowGroupingTotal
+)
+
+type EnergyFlowType int
+
+const (
+	EnergyFlowSolarToHome EnergyFlowType = iota
+	EnergyFlowSolarToBattery
+	EnergyFlowSolarToGrid
+	EnergyFlowGridToHome
+	EnergyFlowGridToBattery
+	EnergyFlowBatteryToHome
+	EnergyFlowBatteryToGrid
+)
+
+type EnergyFlowsArgs struct {
+	InverterSerialNumber string
+	StartTime            time.Time
+	EndTime              time.Time
+	Grouping             EnergyFlowGrouping
+	// Types restricts the flows returned by the API, all flows are returned when empty.
+	Types []EnergyFlowType
+}
+
+type energyFlowsBody struct {
+	StartTime string             `json:"start_time"`
+	EndTime   string             `json:"end_time"`
+	Grouping  EnergyFlowGrouping `json:"grouping"`
+	Types     []EnergyFlowType   `json:"types,omitempty"`
+}
+
+// EnergyFlow holds the energy in kWh moved between sources during one group.
+type EnergyFlow struct {
+	StartTime      time.Time
+	EndTime        time.Time
+	SolarToHome    float64
+	SolarToBattery float64
+	SolarToGrid    float64
+	GridToHome     float64
+	GridToBattery  float64
+	BatteryToHome  float64
+	BatteryToGrid  float64
+}
+
+func (f *EnergyFlow) set(t EnergyFlowType, v float64) {
+	switch t {
+	case EnergyFlowSolarToHome:
+		f.SolarToHome = v
+	case EnergyFlowSolarToBattery:
+		f.SolarToBattery = v
+	case EnergyFlowSolarToGrid:
+		f.SolarToGrid = v
+	case EnergyFlowGridToHome:
+		f.GridToHome = v
+	case EnergyFlowGridToBattery:
+		f.GridToBattery = v
+	case EnergyFlowBatteryToHome:
+		f.BatteryToHome = v
+	case EnergyFlowBatteryToGrid:
+		f.BatteryToGrid = v
+	}
+}
+
+type energyFlowJSON struct {
+	StartTime string          `json:"start_time"`
+	EndTime   string          `json:"end_time"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (f *EnergyFlow) UnmarshalJSON(b []byte) error {
+	var raw energyFlowJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if f.StartTime, err = time.Parse(energyFlowsTimeLayout, raw.StartTime); err != nil {
+		return err
+	}
+	if f.EndTime, err = time.Parse(energyFlowsTimeLayout, raw.EndTime); err != nil {
+		return err
+	}
+
+	values, err := decodeIndexed[float64](raw.Data)
+	if err != nil {
+		return err
+	}
+	for i, v := range values {
+		f.set(EnergyFlowType(i), v)
+	}
+	return nil
+}
+
+type EnergyFlowsResponse struct {
+	Data []*EnergyFlow `json:"data"`
+}
+
+func (r *EnergyFlowsResponse) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	flows, err := decodeIndexed[*EnergyFlow](raw.Data)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]int, 0, len(flows))
+	for k := range flows {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	r.Data = make([]*EnergyFlow, 0, len(keys))
+	for _, k := range keys {
+		r.Data = append(r.Data, flows[k])
+	}
+	return nil
+}
+
+// decodeIndexed decodes a JSON list that the API may encode either as an array
+// or as an object keyed by the item index.
+func decodeIndexed[T any](b []byte) (map[int]T, error) {
+	b = bytes.TrimSpace(b)
+	out := make(map[int]T)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return out, nil
+	}
+
+	if b[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(b, &items); err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			out[i] = item
+		}
+		return out, nil
+	}
+
+	var items map[string]T
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, err
+	}
+	for k, item := range items {
+		i, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected index %q: %w", k, err)
+		}
+		out[i] = item
+	}
+	return out, nil
+}
+
+func (c *Client) EnergyFlows(ctx context.Context, args *EnergyFlowsArgs) (*EnergyFlowsResponse, error) {
+	u := fmt.Sprintf(fmtEnergyFlows, c.baseURL, args.InverterSerialNumber)
+
+	b, err := json.Marshal(&energyFlowsBody{
+		StartTime: args.StartTime.Format(energyFlowsTimeLayout),
+		EndTime:   args.EndTime.Format(energyFlowsTimeLayout),
+		Grouping:  args.Grouping,
+		Types:     args.Types,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(EnergyFlowsResponse)
+	if err := c.do(markIdempotent(req), res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
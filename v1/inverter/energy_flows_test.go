@@ -0,0 +1,77 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestClient_EnergyFlows(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		args := &inverter.EnergyFlowsArgs{
+			InverterSerialNumber: "inverter-1",
+			StartTime:            time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:              time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC),
+			Grouping:             inverter.EnergyFlowGroupingDaily,
+		}
+
+		testURL := fmt.Sprintf(
+			"%s/inverter/%s/energy-flows",
+			baseURL,
+			args.InverterSerialNumber,
+		)
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/energy_flows_200.json",
+			http.StatusOK,
+			testURL,
+			`{ "start_time":"2024-10-01 00:00", "end_time":"2024-10-03 00:00", "grouping":1 }`,
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.EnergyFlows(context.Background(), args)
+		require.NoError(t, err)
+		expected := &inverter.EnergyFlowsResponse{
+			Data: []*inverter.EnergyFlow{
+				{
+					StartTime:      time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+					EndTime:        time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC),
+					SolarToHome:    5.2,
+					SolarToBattery: 3.1,
+					SolarToGrid:    1.4,
+					GridToHome:     2.7,
+					GridToBattery:  4.5,
+					BatteryToHome:  6.3,
+					BatteryToGrid:  0.8,
+				},
+				{
+					StartTime:      time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC),
+					EndTime:        time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC),
+					SolarToHome:    4.9,
+					SolarToBattery: 2.2,
+					SolarToGrid:    0,
+					GridToHome:     3.3,
+					GridToBattery:  4.1,
+					BatteryToHome:  5.7,
+					BatteryToGrid:  0,
+				},
+			},
+		}
+		require.Equal(t, expected, data)
+	})
+}
@@ -0,0 +1,156 @@
+package inverter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+
+	ErrWriteRejected = errors.New("write rejected by inverter")
+	ErrUnsupported   = errors.New("operation not supported by inverter")
+
+	ErrSettingNotFound  = errors.New("setting not found")
+	ErrAmbiguousSetting = errors.New("setting name matches more than one setting")
+
+	ErrOverrideActive = errors.New("override already active")
+)
+
+// APIError is returned for any response outside the 2xx range. Use errors.Is
+// with the sentinel errors above to branch on the kind of failure.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Errors holds per-field validation messages, keyed by field name.
+	Errors map[string][]string
+	Header http.Header
+	Body   []byte
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = string(e.Body)
+	}
+
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("unexpected status code: %d body: %s", e.StatusCode, msg)
+	}
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	details := make([]string, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, fmt.Sprintf("%s: %s", field, strings.Join(e.Errors[field], ", ")))
+	}
+	return fmt.Sprintf("unexpected status code: %d body: %s (%s)", e.StatusCode, msg, strings.Join(details, "; "))
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+func (e *SettingValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// WriteError is returned by strict clients when the API accepted a setting
+// write but the inverter did not confirm it.
+type WriteError struct {
+	InverterSerialNumber string
+	SettingID            string
+	Value                any
+	// Message is the reason reported by the inverter, e.g. a dongle timeout.
+	Message string
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf(
+		"inverter %s did not confirm write of setting %s to %v: %s",
+		e.InverterSerialNumber,
+		e.SettingID,
+		e.Value,
+		e.Message,
+	)
+}
+
+func (e *WriteError) Is(target error) bool {
+	return target == ErrWriteRejected
+}
+
+// UnconfirmedError is returned by strict clients when the API accepted a
+// command or preset but the inverter did not confirm it.
+type UnconfirmedError struct {
+	InverterSerialNumber string
+	// Operation names what was sent, e.g. "command pause-battery".
+	Operation string
+	Message   string
+}
+
+func (e *UnconfirmedError) Error() string {
+	return fmt.Sprintf(
+		"inverter %s did not confirm %s: %s",
+		e.InverterSerialNumber,
+		e.Operation,
+		e.Message,
+	)
+}
+
+func (e *UnconfirmedError) Is(target error) bool {
+	return target == ErrWriteRejected
+}
+
+type apiErrorBody struct {
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+func newAPIError(resp *http.Response) error {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       b,
+	}
+
+	var body apiErrorBody
+	if err := json.Unmarshal(b, &body); err == nil {
+		apiErr.Message = body.Message
+		apiErr.Errors = body.Errors
+	}
+
+	return apiErr
+}
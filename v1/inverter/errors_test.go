@@ -0,0 +1,197 @@
+package inverter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		sentinel   error
+	}{
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, sentinel: inverter.ErrUnauthorized},
+		{name: "forbidden", statusCode: http.StatusForbidden, sentinel: inverter.ErrForbidden},
+		{name: "not found", statusCode: http.StatusNotFound, sentinel: inverter.ErrNotFound},
+		{name: "validation", statusCode: http.StatusUnprocessableEntity, sentinel: inverter.ErrValidation},
+		{name: "rate limited", statusCode: http.StatusTooManyRequests, sentinel: inverter.ErrRateLimited},
+		{name: "server", statusCode: http.StatusBadGateway, sentinel: inverter.ErrServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := &inverter.APIError{StatusCode: tt.statusCode}
+			require.ErrorIs(t, err, tt.sentinel)
+			for _, other := range tests {
+				if other.sentinel != tt.sentinel {
+					require.False(t, errors.Is(err, other.sentinel))
+				}
+			}
+		})
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unauthorized", func(t *testing.T) {
+		t.Parallel()
+
+		args := &inverter.SystemDataLatestArgs{
+			InverterSerialNumber: "inverter-1",
+		}
+
+		testURL := fmt.Sprintf("%s/inverter/%s/system-data/latest", baseURL, args.InverterSerialNumber)
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/unauthenticated_401.json",
+			http.StatusUnauthorized,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		_, err := cl.SystemDataLatest(context.Background(), args)
+		require.ErrorIs(t, err, inverter.ErrUnauthorized)
+
+		var apiErr *inverter.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, "Unauthenticated.", apiErr.Message)
+		require.Equal(t, "application/json", apiErr.Header.Get("Content-Type"))
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		args := inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 150)
+
+		testURL := fmt.Sprintf(
+			"%s/inverter/%s/settings/%s/write",
+			baseURL,
+			args.InverterSerialNumber,
+			args.SettingID,
+		)
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/write_charge_limit_422.json",
+			http.StatusUnprocessableEntity,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		_, err := cl.WriteSettingChargeLimit(context.Background(), args)
+		require.ErrorIs(t, err, inverter.ErrValidation)
+
+		var apiErr *inverter.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+		require.Equal(t, "The given data was invalid.", apiErr.Message)
+		require.Equal(t, map[string][]string{"value": {"Value must be between 0 and 100"}}, apiErr.Errors)
+	})
+}
+
+func TestClient_StrictWrites(t *testing.T) {
+	t.Parallel()
+
+	args := inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeEnabled, true)
+	testURL := fmt.Sprintf(
+		"%s/inverter/%s/settings/%s/write",
+		baseURL,
+		args.InverterSerialNumber,
+		args.SettingID,
+	)
+
+	t.Run("unconfirmed write fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/write_charge_enabled_failed_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithStrictWrites(),
+		)
+
+		_, err := cl.WriteSettingChargeEnabled(context.Background(), args)
+		require.ErrorIs(t, err, inverter.ErrWriteRejected)
+
+		var writeErr *inverter.WriteError
+		require.ErrorAs(t, err, &writeErr)
+		require.Equal(t, &inverter.WriteError{
+			InverterSerialNumber: "inverter-1",
+			SettingID:            inverter.DefaultSettingChargeEnabled,
+			Value:                true,
+			Message:              "Failed to communicate with the inverter",
+		}, writeErr)
+	})
+
+	t.Run("unconfirmed write without strict mode", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/write_charge_enabled_failed_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.WriteSettingChargeEnabled(context.Background(), args)
+		require.NoError(t, err)
+		require.False(t, data.Data.Success)
+	})
+
+	t.Run("confirmed write", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/write_charge_enabled_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithStrictWrites(),
+		)
+
+		data, err := cl.WriteSettingChargeEnabled(context.Background(), args)
+		require.NoError(t, err)
+		require.True(t, data.Data.Success)
+	})
+}
@@ -0,0 +1,131 @@
+package inverter
+
+import (
+	"strings"
+)
+
+type EventCode string
+
+const (
+	EventCodeUnknown EventCode = "unknown"
+
+	EventCodeBMSCommunicationFail   EventCode = "bms_communication_fail"
+	EventCodeMeterCommunicationFail EventCode = "meter_communication_fail"
+	EventCodeDSPCommunicationFail   EventCode = "dsp_communication_fail"
+	EventCodeARMCommunicationFail   EventCode = "arm_communication_fail"
+
+	EventCodeBatteryVoltageLow       EventCode = "battery_voltage_low"
+	EventCodeBatteryVoltageHigh      EventCode = "battery_voltage_high"
+	EventCodeBatteryOverTemperature  EventCode = "battery_over_temperature"
+	EventCodeBatteryUnderTemperature EventCode = "battery_under_temperature"
+	EventCodeBatteryOpen             EventCode = "battery_open"
+	EventCodeBatteryReversed         EventCode = "battery_reversed"
+	EventCodeBMSAlarm                EventCode = "bms_alarm"
+
+	EventCodeGridVoltageHigh   EventCode = "grid_voltage_high"
+	EventCodeGridVoltageLow    EventCode = "grid_voltage_low"
+	EventCodeGridFrequencyHigh EventCode = "grid_frequency_high"
+	EventCodeGridFrequencyLow  EventCode = "grid_frequency_low"
+	EventCodeGridLoss          EventCode = "grid_loss"
+
+	EventCodeOverTemperature EventCode = "over_temperature"
+	EventCodeOverLoad        EventCode = "over_load"
+	EventCodeGroundFault     EventCode = "ground_fault"
+	EventCodeFanFault        EventCode = "fan_fault"
+
+	EventCodePVIsolationLow EventCode = "pv_isolation_low"
+	EventCodePVOverVoltage  EventCode = "pv_over_voltage"
+)
+
+type EventSeverity int
+
+const (
+	EventSeverityInfo EventSeverity = iota
+	EventSeverityWarning
+	EventSeverityCritical
+)
+
+func (s EventSeverity) String() string {
+	switch s {
+	case EventSeverityInfo:
+		return "info"
+	case EventSeverityWarning:
+		return "warning"
+	case EventSeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+type EventCategory string
+
+const (
+	EventCategoryUnknown       EventCategory = "unknown"
+	EventCategoryCommunication EventCategory = "communication"
+	EventCategoryBattery       EventCategory = "battery"
+	EventCategoryGrid          EventCategory = "grid"
+	EventCategoryInverter      EventCategory = "inverter"
+	EventCategorySolar         EventCategory = "solar"
+)
+
+type EventInfo struct {
+	Code     EventCode
+	Severity EventSeverity
+	Category EventCategory
+}
+
+// eventInfos maps the normalized event text reported by the API to its code.
+var eventInfos = map[string]EventInfo{
+	"bms communication fail":     {EventCodeBMSCommunicationFail, EventSeverityWarning, EventCategoryCommunication},
+	"electricity meter com fail": {EventCodeMeterCommunicationFail, EventSeverityWarning, EventCategoryCommunication},
+	"meter communication fail":   {EventCodeMeterCommunicationFail, EventSeverityWarning, EventCategoryCommunication},
+	"dsp communication fail":     {EventCodeDSPCommunicationFail, EventSeverityCritical, EventCategoryCommunication},
+	"arm communication fail":     {EventCodeARMCommunicationFail, EventSeverityCritical, EventCategoryCommunication},
+
+	"battery voltage low":       {EventCodeBatteryVoltageLow, EventSeverityWarning, EventCategoryBattery},
+	"battery voltage high":      {EventCodeBatteryVoltageHigh, EventSeverityCritical, EventCategoryBattery},
+	"battery over temperature":  {EventCodeBatteryOverTemperature, EventSeverityCritical, EventCategoryBattery},
+	"battery under temperature": {EventCodeBatteryUnderTemperature, EventSeverityWarning, EventCategoryBattery},
+	"battery open":              {EventCodeBatteryOpen, EventSeverityCritical, EventCategoryBattery},
+	"battery reversed":          {EventCodeBatteryReversed, EventSeverityCritical, EventCategoryBattery},
+	"bms alarm":                 {EventCodeBMSAlarm, EventSeverityCritical, EventCategoryBattery},
+
+	"grid voltage high":   {EventCodeGridVoltageHigh, EventSeverityWarning, EventCategoryGrid},
+	"grid voltage low":    {EventCodeGridVoltageLow, EventSeverityWarning, EventCategoryGrid},
+	"grid frequency high": {EventCodeGridFrequencyHigh, EventSeverityWarning, EventCategoryGrid},
+	"grid frequency low":  {EventCodeGridFrequencyLow, EventSeverityWarning, EventCategoryGrid},
+	"grid loss":           {EventCodeGridLoss, EventSeverityWarning, EventCategoryGrid},
+	"no utility":          {EventCodeGridLoss, EventSeverityWarning, EventCategoryGrid},
+
+	"over temperature":   {EventCodeOverTemperature, EventSeverityCritical, EventCategoryInverter},
+	"inverter over load": {EventCodeOverLoad, EventSeverityCritical, EventCategoryInverter},
+	"over load":          {EventCodeOverLoad, EventSeverityCritical, EventCategoryInverter},
+	"ground fault":       {EventCodeGroundFault, EventSeverityCritical, EventCategoryInverter},
+	"fan fault":          {EventCodeFanFault, EventSeverityWarning, EventCategoryInverter},
+
+	"pv isolation low": {EventCodePVIsolationLow, EventSeverityCritical, EventCategorySolar},
+	"pv over voltage":  {EventCodePVOverVoltage, EventSeverityCritical, EventCategorySolar},
+}
+
+// LookupEvent maps the free text of an event to its code, severity and
+// category. Unrecognised events are reported as EventCodeUnknown with warning
+// severity so they are not silently dropped by alerting.
+func LookupEvent(name string) EventInfo {
+	if info, ok := eventInfos[strings.ToLower(strings.Join(strings.Fields(name), " "))]; ok {
+		return info
+	}
+	return EventInfo{
+		Code:     EventCodeUnknown,
+		Severity: EventSeverityWarning,
+		Category: EventCategoryUnknown,
+	}
+}
+
+func (e *Event) Info() EventInfo {
+	return LookupEvent(e.Event)
+}
+
+func (e *Event) Code() EventCode {
+	return e.Info().Code
+}
@@ -0,0 +1,94 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestLookupEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected inverter.EventInfo
+	}{
+		{
+			name: "BMS Communication Fail",
+			expected: inverter.EventInfo{
+				Code:     inverter.EventCodeBMSCommunicationFail,
+				Severity: inverter.EventSeverityWarning,
+				Category: inverter.EventCategoryCommunication,
+			},
+		},
+		{
+			name: "battery  voltage LOW",
+			expected: inverter.EventInfo{
+				Code:     inverter.EventCodeBatteryVoltageLow,
+				Severity: inverter.EventSeverityWarning,
+				Category: inverter.EventCategoryBattery,
+			},
+		},
+		{
+			name: "Battery Over Temperature",
+			expected: inverter.EventInfo{
+				Code:     inverter.EventCodeBatteryOverTemperature,
+				Severity: inverter.EventSeverityCritical,
+				Category: inverter.EventCategoryBattery,
+			},
+		},
+		{
+			name: "Something New",
+			expected: inverter.EventInfo{
+				Code:     inverter.EventCodeUnknown,
+				Severity: inverter.EventSeverityWarning,
+				Category: inverter.EventCategoryUnknown,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, inverter.LookupEvent(tt.name))
+		})
+	}
+}
+
+func TestEvent_IsOngoing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("null and missing end time", func(t *testing.T) {
+		t.Parallel()
+
+		testURL := fmt.Sprintf("%s/inverter/%s/events", baseURL, "inverter-1")
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/events_ongoing_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.Events(context.Background(), &inverter.EventsArgs{InverterSerialNumber: "inverter-1"})
+		require.NoError(t, err)
+		require.Len(t, data.Data, 3)
+
+		require.True(t, data.Data[0].IsOngoing())
+		require.Nil(t, data.Data[0].EndTime)
+		require.True(t, data.Data[1].IsOngoing())
+		require.False(t, data.Data[2].IsOngoing())
+		require.Equal(t, inverter.EventCodeMeterCommunicationFail, data.Data[2].Code())
+	})
+}
@@ -0,0 +1,363 @@
+package inverter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+type EventNotificationKind int
+
+const (
+	EventNew EventNotificationKind = iota
+	EventResolved
+	EventStillOngoing
+)
+
+func (k EventNotificationKind) String() string {
+	switch k {
+	case EventNew:
+		return "new"
+	case EventResolved:
+		return "resolved"
+	case EventStillOngoing:
+		return "still_ongoing"
+	default:
+		return "unknown"
+	}
+}
+
+type EventNotification struct {
+	InverterSerialNumber string
+	Kind                 EventNotificationKind
+	Event                *Event
+}
+
+type EventCursorEntry struct {
+	StartTime time.Time `json:"start_time"`
+	Ongoing   bool      `json:"ongoing"`
+}
+
+// EventCursor is the state an EventWatcher persists between polls.
+type EventCursor struct {
+	// Since is the start time from which events are fetched.
+	Since time.Time `json:"since"`
+	// Seen holds the events already notified, keyed by event name and start time.
+	Seen map[string]*EventCursorEntry `json:"seen"`
+}
+
+// CursorStore persists event cursors so a restarted watcher does not notify
+// the same events again. Load returns a nil cursor when none was saved.
+type CursorStore interface {
+	Load(ctx context.Context, serialNumber string) (*EventCursor, error)
+	Save(ctx context.Context, serialNumber string, cursor *EventCursor) error
+}
+
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string][]byte
+}
+
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{
+		cursors: make(map[string][]byte),
+	}
+}
+
+func (s *MemoryCursorStore) Load(_ context.Context, serialNumber string) (*EventCursor, error) {
+	s.mu.Lock()
+	b, ok := s.cursors[serialNumber]
+	s.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	cursor := new(EventCursor)
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+func (s *MemoryCursorStore) Save(_ context.Context, serialNumber string, cursor *EventCursor) error {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cursors[serialNumber] = b
+	s.mu.Unlock()
+	return nil
+}
+
+// FileCursorStore keeps one JSON file per inverter in Dir.
+type FileCursorStore struct {
+	Dir string
+}
+
+func NewFileCursorStore(dir string) *FileCursorStore {
+	return &FileCursorStore{Dir: dir}
+}
+
+func (s *FileCursorStore) path(serialNumber string) string {
+	return filepath.Join(s.Dir, "events-"+serialNumber+".json")
+}
+
+func (s *FileCursorStore) Load(_ context.Context, serialNumber string) (*EventCursor, error) {
+	b, err := os.ReadFile(s.path(serialNumber))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := new(EventCursor)
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+func (s *FileCursorStore) Save(_ context.Context, serialNumber string, cursor *EventCursor) error {
+	return writeFileAtomic(s.path(serialNumber), cursor)
+}
+
+// writeFileAtomic writes v as JSON through a temporary file so a crash never
+// leaves a truncated file behind.
+func writeFileAtomic(path string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+type EventWatcherOption func(*EventWatcher)
+
+// WithCursorStore persists the watcher state, by default it is kept in memory.
+func WithCursorStore(s CursorStore) EventWatcherOption {
+	return func(w *EventWatcher) {
+		w.store = s
+	}
+}
+
+// WithEventLookback sets how far back the first poll looks when no cursor
+// was saved. Defaults to 24 hours.
+func WithEventLookback(d time.Duration) EventWatcherOption {
+	return func(w *EventWatcher) {
+		w.lookback = d
+	}
+}
+
+// WithEventErrorHandler is called with errors from polls made by Run and
+// Watch, which otherwise retry silently on the next tick.
+func WithEventErrorHandler(fn func(error)) EventWatcherOption {
+	return func(w *EventWatcher) {
+		w.onError = fn
+	}
+}
+
+// EventWatcher polls the events of an inverter and notifies new events,
+// resolved events and events still ongoing since the previous poll.
+type EventWatcher struct {
+	client       *Client
+	serialNumber string
+	interval     time.Duration
+	store        CursorStore
+	lookback     time.Duration
+	onError      func(error)
+
+	mu     sync.Mutex
+	cursor *EventCursor
+}
+
+// NewEventWatcher returns an error when interval is not positive.
+func NewEventWatcher(
+	c *Client,
+	serialNumber string,
+	interval time.Duration,
+	opts ...EventWatcherOption,
+) (*EventWatcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid poll interval %s: must be positive", interval)
+	}
+
+	w := &EventWatcher{
+		client:       c,
+		serialNumber: serialNumber,
+		interval:     interval,
+		store:        NewMemoryCursorStore(),
+		lookback:     24 * time.Hour,
+		onError:      func(error) {},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w, nil
+}
+
+func eventKey(e *Event) string {
+	return e.Event + "|" + e.StartTime.UTC().Format(time.RFC3339Nano)
+}
+
+// Poll fetches events once and returns the notifications, oldest first. The
+// cursor is saved only once all pages were fetched.
+func (w *EventWatcher) Poll(ctx context.Context) ([]*EventNotification, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cursor == nil {
+		cursor, err := w.store.Load(ctx, w.serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		if cursor == nil {
+			cursor = &EventCursor{Since: time.Now().Add(-w.lookback).UTC()}
+		}
+		if cursor.Seen == nil {
+			cursor.Seen = make(map[string]*EventCursorEntry)
+		}
+		w.cursor = cursor
+	}
+
+	since := w.cursor.Since
+	var events []*Event
+	for e, err := range w.client.EventSeq(ctx, &EventsArgs{InverterSerialNumber: w.serialNumber, Start: &since}) {
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartTime.Before(events[j].StartTime)
+	})
+
+	var notifications []*EventNotification
+	notify := func(kind EventNotificationKind, e *Event) {
+		notifications = append(notifications, &EventNotification{
+			InverterSerialNumber: w.serialNumber,
+			Kind:                 kind,
+			Event:                e,
+		})
+	}
+
+	next := make(map[string]*EventCursorEntry, len(w.cursor.Seen))
+	for k, v := range w.cursor.Seen {
+		next[k] = v
+	}
+	for _, e := range events {
+		key := eventKey(e)
+		ongoing := e.IsOngoing()
+		seen, ok := next[key]
+		switch {
+		case !ok:
+			notify(EventNew, e)
+		case seen.Ongoing && !ongoing:
+			notify(EventResolved, e)
+		case seen.Ongoing && ongoing:
+			notify(EventStillOngoing, e)
+		}
+		next[key] = &EventCursorEntry{StartTime: e.StartTime, Ongoing: ongoing}
+	}
+
+	cursor := &EventCursor{Since: since, Seen: next}
+	cursor.advance()
+	if err := w.store.Save(ctx, w.serialNumber, cursor); err != nil {
+		return nil, err
+	}
+	w.cursor = cursor
+
+	return notifications, nil
+}
+
+// advance moves Since to the oldest ongoing event, or to the newest event
+// when none is ongoing, and forgets events that will no longer be fetched.
+func (c *EventCursor) advance() {
+	var oldestOngoing, newest time.Time
+	for _, entry := range c.Seen {
+		if entry.Ongoing && (oldestOngoing.IsZero() || entry.StartTime.Before(oldestOngoing)) {
+			oldestOngoing = entry.StartTime
+		}
+		if entry.StartTime.After(newest) {
+			newest = entry.StartTime
+		}
+	}
+
+	switch {
+	case !oldestOngoing.IsZero():
+		c.Since = oldestOngoing
+	case newest.After(c.Since):
+		c.Since = newest
+	}
+
+	for k, entry := range c.Seen {
+		if entry.StartTime.Before(c.Since) {
+			delete(c.Seen, k)
+		}
+	}
+}
+
+// Run polls immediately and then at every interval, calling fn for each
+// notification, until ctx is done.
+func (w *EventWatcher) Run(ctx context.Context, fn func(*EventNotification)) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		notifications, err := w.Poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.onError(err)
+		}
+		for _, n := range notifications {
+			fn(n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Watch runs the watcher in a goroutine and delivers notifications on the
+// returned channel, which is closed once ctx is done.
+func (w *EventWatcher) Watch(ctx context.Context) <-chan *EventNotification {
+	ch := make(chan *EventNotification)
+	go func() {
+		defer close(ch)
+		_ = w.Run(ctx, func(n *EventNotification) {
+			select {
+			case ch <- n:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch
+}
@@ -0,0 +1,165 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+type notification struct {
+	Kind  inverter.EventNotificationKind
+	Event string
+}
+
+func summarize(ns []*inverter.EventNotification) []notification {
+	out := make([]notification, 0, len(ns))
+	for _, n := range ns {
+		out = append(out, notification{Kind: n.Kind, Event: n.Event.Event})
+	}
+	return out
+}
+
+func TestEventWatcher_Poll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("new, resolved and still ongoing", func(t *testing.T) {
+		t.Parallel()
+
+		eventsURL := fmt.Sprintf("%s/inverter/%s/events", baseURL, "inverter-1")
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        eventsURL + "?page=1&start=2024-10-01T00%3A00%3A00Z",
+				Path:       "testdata/events_ongoing_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:        eventsURL + "?page=1&start=2024-10-03T11%3A33%3A07Z",
+				Path:       "testdata/events_resolved_200.json",
+				StatusCode: http.StatusOK,
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		ctx := context.Background()
+		store := inverter.NewMemoryCursorStore()
+		require.NoError(t, store.Save(ctx, "inverter-1", &inverter.EventCursor{
+			Since: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		}))
+
+		w, err := inverter.NewEventWatcher(cl, "inverter-1", time.Minute, inverter.WithCursorStore(store))
+		require.NoError(t, err)
+
+		ns, err := w.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []notification{
+			{Kind: inverter.EventNew, Event: "Electricity Meter Com Fail"},
+			{Kind: inverter.EventNew, Event: "Battery Voltage Low"},
+			{Kind: inverter.EventNew, Event: "BMS Communication Fail"},
+		}, summarize(ns))
+
+		ns, err = w.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []notification{
+			{Kind: inverter.EventResolved, Event: "Battery Voltage Low"},
+			{Kind: inverter.EventStillOngoing, Event: "BMS Communication Fail"},
+		}, summarize(ns))
+
+		// a restarted watcher does not notify the same events again
+		restarted, err := inverter.NewEventWatcher(cl, "inverter-1", time.Minute, inverter.WithCursorStore(store))
+		require.NoError(t, err)
+		ns, err = restarted.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []notification{
+			{Kind: inverter.EventStillOngoing, Event: "BMS Communication Fail"},
+		}, summarize(ns))
+	})
+}
+
+func TestEventWatcher_Watch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("closes on context cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		eventsURL := fmt.Sprintf("%s/inverter/%s/events", baseURL, "inverter-1")
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        eventsURL + "?page=1&start=2024-10-01T00%3A00%3A00Z",
+				Path:       "testdata/events_ongoing_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:        eventsURL + "?page=1&start=2024-10-03T11%3A33%3A07Z",
+				Path:       "testdata/events_ongoing_200.json",
+				StatusCode: http.StatusOK,
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		store := inverter.NewMemoryCursorStore()
+		require.NoError(t, store.Save(ctx, "inverter-1", &inverter.EventCursor{
+			Since: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		}))
+
+		w, err := inverter.NewEventWatcher(cl, "inverter-1", time.Millisecond, inverter.WithCursorStore(store))
+		require.NoError(t, err)
+		ch := w.Watch(ctx)
+
+		n := <-ch
+		require.Equal(t, inverter.EventNew, n.Kind)
+		cancel()
+
+		for range ch {
+		}
+	})
+}
+
+func TestFileCursorStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := inverter.NewFileCursorStore(t.TempDir())
+
+	cursor, err := store.Load(ctx, "inverter-1")
+	require.NoError(t, err)
+	require.Nil(t, cursor)
+
+	expected := &inverter.EventCursor{
+		Since: time.Date(2024, 10, 3, 11, 33, 7, 0, time.UTC),
+		Seen: map[string]*inverter.EventCursorEntry{
+			"BMS Communication Fail|2024-10-03T11:33:07Z": {
+				StartTime: time.Date(2024, 10, 3, 11, 33, 7, 0, time.UTC),
+				Ongoing:   true,
+			},
+		},
+	}
+	require.NoError(t, store.Save(ctx, "inverter-1", expected))
+
+	cursor, err = store.Load(ctx, "inverter-1")
+	require.NoError(t, err)
+	require.Equal(t, expected, cursor)
+	t.Run("invalid interval", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := inverter.NewEventWatcher(inverter.NewClient(testToken), "inverter-1", 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+package inverter
+
+import (
+	"context"
+	"iter"
+)
+
+// EventPages returns an iterator over every page of Events, starting at
+// args.Page (or the first page). Pages are fetched lazily, so breaking out of
+// the loop stops further requests. Iteration ends after the last page, or
+// after yielding an error, including the context error once ctx is done.
+func (c *Client) EventPages(ctx context.Context, args *EventsArgs) iter.Seq2[*EventsResponse, error] {
+	return func(yield func(*EventsResponse, error) bool) {
+		pageArgs := *args
+		page := 1
+		if args.Page != nil {
+			page = *args.Page
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			pageArgs.Page = &page
+			res, err := c.Events(ctx, &pageArgs)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(res, nil) {
+				return
+			}
+			if len(res.Data) == 0 || !res.Meta.HasNext() {
+				return
+			}
+			page = res.Meta.CurrentPage + 1
+		}
+	}
+}
+
+// EventSeq returns an iterator over the events of every page matching args.
+func (c *Client) EventSeq(ctx context.Context, args *EventsArgs) iter.Seq2[*Event, error] {
+	return func(yield func(*Event, error) bool) {
+		for res, err := range c.EventPages(ctx, args) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, e := range res.Data {
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AllEvents returns an iterator over every event of an inverter.
+func (c *Client) AllEvents(ctx context.Context, serialNumber string) iter.Seq2[*Event, error] {
+	return c.EventSeq(ctx, &EventsArgs{InverterSerialNumber: serialNumber})
+}
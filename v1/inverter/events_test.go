@@ -0,0 +1,96 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func newEventPagesClient(t *testing.T) (*inverter.Client, *RouterRoundTripper, string) {
+	t.Helper()
+
+	testURL := fmt.Sprintf("%s/inverter/%s/events", baseURL, "inverter-1")
+	mockHTTPClient, rrt := newRouterClient(
+		t,
+		&mockRoute{URL: testURL + "?page=1", Path: "testdata/events_page_1_200.json", StatusCode: http.StatusOK},
+		&mockRoute{URL: testURL + "?page=2", Path: "testdata/events_page_2_200.json", StatusCode: http.StatusOK},
+	)
+
+	return inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient)), rrt, testURL
+}
+
+func TestClient_EventPages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		cl, _, _ := newEventPagesClient(t)
+
+		var pages []int
+		for res, err := range cl.EventPages(context.Background(), &inverter.EventsArgs{InverterSerialNumber: "inverter-1"}) {
+			require.NoError(t, err)
+			pages = append(pages, res.Meta.CurrentPage)
+		}
+		require.Equal(t, []int{1, 2}, pages)
+	})
+}
+
+func TestClient_AllEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		cl, _, _ := newEventPagesClient(t)
+
+		var names []string
+		for e, err := range cl.AllEvents(context.Background(), "inverter-1") {
+			require.NoError(t, err)
+			names = append(names, e.Event)
+		}
+		require.Equal(t, []string{"Battery Voltage Low", "BMS Communication Fail", "Electricity Meter Com Fail"}, names)
+	})
+
+	t.Run("break stops fetching", func(t *testing.T) {
+		t.Parallel()
+
+		cl, rrt, testURL := newEventPagesClient(t)
+
+		for e, err := range cl.AllEvents(context.Background(), "inverter-1") {
+			require.NoError(t, err)
+			require.Equal(t, "Battery Voltage Low", e.Event)
+			break
+		}
+		require.Equal(t, 1, rrt.Calls(testURL+"?page=1"))
+		require.Equal(t, 0, rrt.Calls(testURL+"?page=2"))
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		cl, rrt, testURL := newEventPagesClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var errs []error
+		count := 0
+		for _, err := range cl.AllEvents(ctx, "inverter-1") {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			count++
+			cancel()
+		}
+		require.Equal(t, 2, count)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], context.Canceled)
+		require.Equal(t, 0, rrt.Calls(testURL+"?page=2"))
+	})
+}
@@ -0,0 +1,174 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const defaultFleetConcurrency = 8
+
+type FleetOption func(*Fleet)
+
+// WithConcurrency bounds how many inverters are called at the same time.
+func WithConcurrency(n int) FleetOption {
+	return func(f *Fleet) {
+		if n > 0 {
+			f.concurrency = n
+		}
+	}
+}
+
+// Fleet runs the same operation against many inverters sharing one client.
+type Fleet struct {
+	client      *Client
+	serials     []string
+	concurrency int
+}
+
+func NewFleet(c *Client, serialNumbers []string, opts ...FleetOption) *Fleet {
+	f := &Fleet{
+		client:      c,
+		serials:     append([]string(nil), serialNumbers...),
+		concurrency: defaultFleetConcurrency,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *Fleet) SerialNumbers() []string {
+	return append([]string(nil), f.serials...)
+}
+
+// FleetResults holds the outcome per serial number: every serial is either in
+// Values or in Errors.
+type FleetResults[T any] struct {
+	Values map[string]T
+	Errors map[string]error
+}
+
+// Err returns a *FleetError when at least one inverter failed.
+func (r *FleetResults[T]) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return &FleetError{Errors: r.Errors}
+}
+
+type FleetError struct {
+	Errors map[string]error
+}
+
+func (e *FleetError) Error() string {
+	serials := make([]string, 0, len(e.Errors))
+	for serial := range e.Errors {
+		serials = append(serials, serial)
+	}
+	sort.Strings(serials)
+
+	msgs := make([]string, 0, len(serials))
+	for _, serial := range serials {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", serial, e.Errors[serial]))
+	}
+	return fmt.Sprintf("%d inverters failed: %s", len(serials), strings.Join(msgs, "; "))
+}
+
+func (e *FleetError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// FanOut calls fn for every inverter of the fleet with bounded concurrency.
+// A failing inverter does not stop the others.
+func FanOut[T any](
+	ctx context.Context,
+	f *Fleet,
+	fn func(ctx context.Context, serialNumber string) (T, error),
+) *FleetResults[T] {
+	res := &FleetResults[T]{
+		Values: make(map[string]T, len(f.serials)),
+		Errors: make(map[string]error),
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, f.concurrency)
+	)
+	for _, serial := range f.serials {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			res.Errors[serial] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			v, err := fn(ctx, serial)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				res.Errors[serial] = err
+				return
+			}
+			res.Values[serial] = v
+		}()
+	}
+	wg.Wait()
+
+	return res
+}
+
+func (f *Fleet) SystemDataLatest(ctx context.Context) *FleetResults[*SystemDataLatestResponse] {
+	return FanOut(ctx, f, func(ctx context.Context, serial string) (*SystemDataLatestResponse, error) {
+		return f.client.SystemDataLatest(ctx, &SystemDataLatestArgs{InverterSerialNumber: serial})
+	})
+}
+
+// Events fetches events for every inverter using args as a template, its
+// InverterSerialNumber is ignored.
+func (f *Fleet) Events(ctx context.Context, args *EventsArgs) *FleetResults[*EventsResponse] {
+	return FanOut(ctx, f, func(ctx context.Context, serial string) (*EventsResponse, error) {
+		serialArgs := *args
+		serialArgs.InverterSerialNumber = serial
+		return f.client.Events(ctx, &serialArgs)
+	})
+}
+
+func WriteFleetSetting[T any](
+	ctx context.Context,
+	f *Fleet,
+	settingID string,
+	value T,
+) *FleetResults[*WriteSettingResponse[T]] {
+	return FanOut(ctx, f, func(ctx context.Context, serial string) (*WriteSettingResponse[T], error) {
+		return WriteSetting(ctx, f.client, NewWriteSettingArgs(serial, settingID, value))
+	})
+}
+
+// WriteFleetSettingByName resolves the setting ID per inverter, which is
+// safer than WriteFleetSetting for fleets mixing inverter models.
+func WriteFleetSettingByName[T any](
+	ctx context.Context,
+	f *Fleet,
+	name SettingName,
+	value T,
+) *FleetResults[*WriteSettingResponse[T]] {
+	return FanOut(ctx, f, func(ctx context.Context, serial string) (*WriteSettingResponse[T], error) {
+		return WriteSettingByName(ctx, f.client, serial, name, value)
+	})
+}
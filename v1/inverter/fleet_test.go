@@ -0,0 +1,135 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestFleet_SystemDataLatest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("partial failure", func(t *testing.T) {
+		t.Parallel()
+
+		urlFor := func(serial string) string {
+			return fmt.Sprintf("%s/inverter/%s/system-data/latest", baseURL, serial)
+		}
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{URL: urlFor("inverter-1"), Path: "testdata/system_data_latest_200.json", StatusCode: http.StatusOK},
+			&mockRoute{URL: urlFor("inverter-2"), Path: "testdata/system_data_latest_200.json", StatusCode: http.StatusOK},
+			&mockRoute{URL: urlFor("inverter-3"), StatusCode: http.StatusNotFound},
+		)
+
+		cl := inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient))
+		fleet := inverter.NewFleet(
+			cl,
+			[]string{"inverter-1", "inverter-2", "inverter-3"},
+			inverter.WithConcurrency(2),
+		)
+
+		res := fleet.SystemDataLatest(context.Background())
+		require.Len(t, res.Values, 2)
+		require.Equal(t, 96, res.Values["inverter-1"].Data.Battery.Percent)
+		require.Equal(t, 96, res.Values["inverter-2"].Data.Battery.Percent)
+		require.Len(t, res.Errors, 1)
+		require.ErrorIs(t, res.Errors["inverter-3"], inverter.ErrNotFound)
+
+		err := res.Err()
+		var fleetErr *inverter.FleetError
+		require.ErrorAs(t, err, &fleetErr)
+		require.ErrorIs(t, err, inverter.ErrNotFound)
+		require.Contains(t, err.Error(), "inverter-3")
+	})
+}
+
+func TestFleet_Events(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		urlFor := func(serial string) string {
+			return fmt.Sprintf("%s/inverter/%s/events?page=1", baseURL, serial)
+		}
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{URL: urlFor("inverter-1"), Path: "testdata/events_200.json", StatusCode: http.StatusOK},
+			&mockRoute{URL: urlFor("inverter-2"), Path: "testdata/events_ongoing_200.json", StatusCode: http.StatusOK},
+		)
+
+		cl := inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient))
+		fleet := inverter.NewFleet(cl, []string{"inverter-1", "inverter-2"})
+
+		page := 1
+		res := fleet.Events(context.Background(), &inverter.EventsArgs{Page: &page})
+		require.NoError(t, res.Err())
+		require.Len(t, res.Values["inverter-1"].Data, 4)
+		require.Len(t, res.Values["inverter-2"].Data, 3)
+	})
+}
+
+func TestWriteFleetSetting(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		urlFor := func(serial string) string {
+			return fmt.Sprintf("%s/inverter/%s/settings/%s/write", baseURL, serial, inverter.DefaultSettingChargeLimit)
+		}
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{
+				URL:              urlFor("inverter-1"),
+				Path:             "testdata/write_charge_limit_200.json",
+				StatusCode:       http.StatusOK,
+				ExpectedBodyJSON: `{"value":100}`,
+			},
+			&mockRoute{
+				URL:              urlFor("inverter-2"),
+				Path:             "testdata/write_charge_limit_200.json",
+				StatusCode:       http.StatusOK,
+				ExpectedBodyJSON: `{"value":100}`,
+			},
+		)
+
+		cl := inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient))
+		fleet := inverter.NewFleet(cl, []string{"inverter-1", "inverter-2"})
+
+		res := inverter.WriteFleetSetting(context.Background(), fleet, inverter.DefaultSettingChargeLimit, 100)
+		require.NoError(t, res.Err())
+		require.Len(t, res.Values, 2)
+		for _, v := range res.Values {
+			require.True(t, v.Data.Success)
+		}
+	})
+}
+
+func TestFanOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancelled context", func(t *testing.T) {
+		t.Parallel()
+
+		fleet := inverter.NewFleet(inverter.NewClient(testToken), []string{"inverter-1", "inverter-2"})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		res := inverter.FanOut(ctx, fleet, func(ctx context.Context, serial string) (string, error) {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			return serial, nil
+		})
+		require.Empty(t, res.Values)
+		require.Len(t, res.Errors, 2)
+		require.ErrorIs(t, res.Err(), context.Canceled)
+	})
+}
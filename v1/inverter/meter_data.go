@@ -0,0 +1,60 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	fmtMeterDataLatest = "%s/inverter/%s/meter-data/latest"
+)
+
+type MeterDataLatestArgs struct {
+	InverterSerialNumber string
+}
+
+type MeterDataGrid struct {
+	Import float64 `json:"import"`
+	Export float64 `json:"export"`
+}
+
+type MeterDataBattery struct {
+	Charge    float64 `json:"charge"`
+	Discharge float64 `json:"discharge"`
+}
+
+// MeterDataEnergy holds energy counters in kWh.
+type MeterDataEnergy struct {
+	Solar       float64           `json:"solar"`
+	Grid        *MeterDataGrid    `json:"grid"`
+	Battery     *MeterDataBattery `json:"battery"`
+	Consumption float64           `json:"consumption"`
+	AcCharge    float64           `json:"ac_charge"`
+}
+
+type MeterData struct {
+	Time  time.Time        `json:"time"`
+	Today *MeterDataEnergy `json:"today"`
+	Total *MeterDataEnergy `json:"total"`
+}
+
+type MeterDataLatestResponse struct {
+	Data *MeterData `json:"data"`
+}
+
+func (c *Client) MeterDataLatest(ctx context.Context, args *MeterDataLatestArgs) (*MeterDataLatestResponse, error) {
+	u := fmt.Sprintf(fmtMeterDataLatest, c.baseURL, args.InverterSerialNumber)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(MeterDataLatestResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,79 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestClient_MeterDataLatest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		args := &inverter.MeterDataLatestArgs{
+			InverterSerialNumber: "inverter-1",
+		}
+
+		testURL := fmt.Sprintf(
+			"%s/inverter/%s/meter-data/latest",
+			baseURL,
+			args.InverterSerialNumber,
+		)
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/meter_data_latest_200.json",
+			http.StatusOK,
+			testURL,
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.MeterDataLatest(context.Background(), args)
+		require.NoError(t, err)
+		expected := &inverter.MeterDataLatestResponse{
+			Data: &inverter.MeterData{
+				Time: time.Date(2024, 10, 17, 15, 22, 3, 0, time.UTC),
+				Today: &inverter.MeterDataEnergy{
+					Solar: 12.4,
+					Grid: &inverter.MeterDataGrid{
+						Import: 3.1,
+						Export: 5.6,
+					},
+					Battery: &inverter.MeterDataBattery{
+						Charge:    4.2,
+						Discharge: 2.9,
+					},
+					Consumption: 9.8,
+					AcCharge:    1.3,
+				},
+				Total: &inverter.MeterDataEnergy{
+					Solar: 3125.4,
+					Grid: &inverter.MeterDataGrid{
+						Import: 4210.2,
+						Export: 812.9,
+					},
+					Battery: &inverter.MeterDataBattery{
+						Charge:    1530.1,
+						Discharge: 1398.7,
+					},
+					Consumption: 6521.3,
+					AcCharge:    702.6,
+				},
+			},
+		}
+		require.Equal(t, expected, data)
+	})
+}
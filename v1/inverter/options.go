@@ -8,8 +8,13 @@ import (
 type Option func(*options)
 
 type options struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL           string
+	httpClient        *http.Client
+	validateWrites    bool
+	strictWrites      bool
+	retry             *RetryPolicy
+	limiter           *RateLimiter
+	checkCapabilities bool
 }
 
 func defaultOptions() *options {
@@ -32,3 +37,50 @@ func WithBaseURL(baseURL string) Option {
 		o.baseURL = baseURL
 	}
 }
+
+// WithSettingValidation validates setting writes against the validation rules
+// returned by ListSettings before sending them.
+func WithSettingValidation() Option {
+	return func(o *options) {
+		o.validateWrites = true
+	}
+}
+
+// WithStrictWrites makes setting writes fail with a *WriteError, and
+// commands and presets with an *UnconfirmedError, when the inverter does not
+// confirm them with success=true.
+func WithStrictWrites() Option {
+	return func(o *options) {
+		o.strictWrites = true
+	}
+}
+
+// WithRetryPolicy retries failed requests on 429, 5xx and network errors.
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithRateLimit limits the client to perSecond requests with bursts of burst.
+// A perSecond of zero or less means no limit, see NewRateLimiter.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(o *options) {
+		o.limiter = NewRateLimiter(perSecond, burst)
+	}
+}
+
+// WithRateLimiter shares l with other clients using the same API token.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(o *options) {
+		o.limiter = l
+	}
+}
+
+// WithCapabilityChecks makes the typed setting methods return ErrUnsupported
+// for operations the inverter model or firmware cannot do.
+func WithCapabilityChecks() Option {
+	return func(o *options) {
+		o.checkCapabilities = true
+	}
+}
@@ -0,0 +1,441 @@
+package inverter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SettingsSnapshot holds the settings an override changed, as they were
+// before it started. Nil fields were left untouched.
+type SettingsSnapshot struct {
+	InverterSerialNumber string        `json:"inverter_serial_number"`
+	TakenAt              time.Time     `json:"taken_at"`
+	Until                time.Time     `json:"until"`
+	ChargeWindow         *TimeWindow   `json:"charge_window,omitempty"`
+	ChargeEnabled        *bool         `json:"charge_enabled,omitempty"`
+	ChargeLimit          *int          `json:"charge_limit,omitempty"`
+	DischargeWindow      *TimeWindow   `json:"discharge_window,omitempty"`
+	EcoEnabled           *bool         `json:"eco_enabled,omitempty"`
+	DischargeEnabled     *bool         `json:"discharge_enabled,omitempty"`
+	BatteryPause         *BatteryPause `json:"battery_pause,omitempty"`
+}
+
+// SnapshotStore persists the snapshot of a running override so that
+// RecoverOverride can restore it after a crash. Load returns a nil snapshot
+// when none was saved.
+type SnapshotStore interface {
+	Load(ctx context.Context, serialNumber string) (*SettingsSnapshot, error)
+	Save(ctx context.Context, serialNumber string, snapshot *SettingsSnapshot) error
+	Delete(ctx context.Context, serialNumber string) error
+}
+
+type MemorySnapshotStore struct {
+	mu        sync.Mutex
+	snapshots map[string][]byte
+}
+
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{
+		snapshots: make(map[string][]byte),
+	}
+}
+
+func (s *MemorySnapshotStore) Load(_ context.Context, serialNumber string) (*SettingsSnapshot, error) {
+	s.mu.Lock()
+	b, ok := s.snapshots[serialNumber]
+	s.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	snapshot := new(SettingsSnapshot)
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *MemorySnapshotStore) Save(_ context.Context, serialNumber string, snapshot *SettingsSnapshot) error {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.snapshots[serialNumber] = b
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemorySnapshotStore) Delete(_ context.Context, serialNumber string) error {
+	s.mu.Lock()
+	delete(s.snapshots, serialNumber)
+	s.mu.Unlock()
+	return nil
+}
+
+// FileSnapshotStore keeps one JSON file per inverter in Dir.
+type FileSnapshotStore struct {
+	Dir string
+}
+
+func NewFileSnapshotStore(dir string) *FileSnapshotStore {
+	return &FileSnapshotStore{Dir: dir}
+}
+
+func (s *FileSnapshotStore) path(serialNumber string) string {
+	return filepath.Join(s.Dir, "override-"+serialNumber+".json")
+}
+
+func (s *FileSnapshotStore) Load(_ context.Context, serialNumber string) (*SettingsSnapshot, error) {
+	b, err := os.ReadFile(s.path(serialNumber))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := new(SettingsSnapshot)
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *FileSnapshotStore) Save(_ context.Context, serialNumber string, snapshot *SettingsSnapshot) error {
+	return writeFileAtomic(s.path(serialNumber), snapshot)
+}
+
+func (s *FileSnapshotStore) Delete(_ context.Context, serialNumber string) error {
+	err := os.Remove(s.path(serialNumber))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+type OverrideArgs struct {
+	InverterSerialNumber string
+	Duration             time.Duration
+	// Charge charges the battery from the grid starting now.
+	Charge bool
+	// ChargeLimit is the battery percent to charge up to, zero leaves the
+	// limit unchanged.
+	ChargeLimit int
+	// Export discharges the battery at full power starting now.
+	Export bool
+	// Mode is the battery mode during the override, empty leaves it
+	// unchanged. Export defaults it to BatteryModeTimedExport.
+	Mode BatteryMode
+	// Location is the time zone of the inverter clock, time.Local when nil.
+	Location *time.Location
+}
+
+type OverrideOption func(*overrideOptions)
+
+type overrideOptions struct {
+	store SnapshotStore
+}
+
+// WithSnapshotStore persists the snapshot, by default it is kept in the
+// memory of the client and lost if the process dies.
+func WithSnapshotStore(s SnapshotStore) OverrideOption {
+	return func(o *overrideOptions) {
+		o.store = s
+	}
+}
+
+// Override is a temporary change of settings that is reverted when its
+// duration ends, the context passed to StartOverride is cancelled or Stop is
+// called.
+type Override struct {
+	client   *Client
+	store    SnapshotStore
+	snapshot *SettingsSnapshot
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+// StartOverride snapshots the settings it is about to change, persists the
+// snapshot and applies the override. It returns ErrOverrideActive when the
+// store already holds a snapshot for the inverter, as the current settings
+// would then be the ones of another override.
+func (c *Client) StartOverride(ctx context.Context, args *OverrideArgs, opts ...OverrideOption) (*Override, error) {
+	conf := &overrideOptions{
+		store: c.snapshots,
+	}
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	if args.Duration <= 0 || args.Duration >= 24*time.Hour {
+		return nil, fmt.Errorf("override duration %s: must be between 0 and 24h", args.Duration)
+	}
+	if !args.Charge && args.ChargeLimit <= 0 && !args.Export && args.Mode == "" {
+		return nil, errors.New("override changes no setting")
+	}
+	if args.Charge && args.Export {
+		return nil, errors.New("override cannot both charge and export")
+	}
+	mode := args.Mode
+	if args.Export && mode == "" {
+		mode = BatteryModeTimedExport
+	}
+
+	serial := args.InverterSerialNumber
+	prev, err := conf.store.Load(ctx, serial)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		return nil, fmt.Errorf("inverter %s until %s: %w", serial, prev.Until.Format(time.RFC3339), ErrOverrideActive)
+	}
+
+	loc := args.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	// the window end is rounded up so it covers the whole duration
+	window := TimeWindow{
+		Start: TimeOfDayOf(now),
+		End:   TimeOfDayOf(now.Add(args.Duration + time.Minute - 1)),
+	}
+	if (args.Charge || args.Export) && window.Disabled() {
+		return nil, fmt.Errorf("override duration %s: window %s would be disabled", args.Duration, window)
+	}
+
+	snapshot, err := c.takeSnapshot(ctx, serial, args, mode)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.TakenAt = now
+	snapshot.Until = now.Add(args.Duration)
+	if err := conf.store.Save(ctx, serial, snapshot); err != nil {
+		return nil, err
+	}
+
+	o := &Override{
+		client:   c,
+		store:    conf.store,
+		snapshot: snapshot,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if err := c.applyOverride(ctx, serial, args, mode, window); err != nil {
+		restoreErr := o.restore(context.WithoutCancel(ctx))
+		return nil, errors.Join(err, restoreErr)
+	}
+
+	go o.wait(ctx, args.Duration)
+	return o, nil
+}
+
+func (c *Client) takeSnapshot(
+	ctx context.Context,
+	serialNumber string,
+	args *OverrideArgs,
+	mode BatteryMode,
+) (*SettingsSnapshot, error) {
+	snapshot := &SettingsSnapshot{InverterSerialNumber: serialNumber}
+
+	if args.Charge {
+		window, err := c.ReadChargeWindow(ctx, serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		enabled, err := readACChargeSetting[bool](ctx, c, serialNumber, SettingNameChargeEnabled)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.ChargeWindow = &window
+		snapshot.ChargeEnabled = &enabled
+	}
+	if args.ChargeLimit > 0 {
+		limit, err := readACChargeSetting[int](ctx, c, serialNumber, SettingNameChargeLimit)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.ChargeLimit = &limit
+	}
+	if args.Export {
+		window, err := c.ReadDischargeWindow(ctx, serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.DischargeWindow = &window
+	}
+	if mode != "" {
+		eco, discharge, err := c.readBatteryToggles(ctx, serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		pause, err := c.readBatteryPause(ctx, serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.EcoEnabled = &eco
+		snapshot.DischargeEnabled = &discharge
+		snapshot.BatteryPause = pause
+	}
+
+	return snapshot, nil
+}
+
+func (c *Client) applyOverride(
+	ctx context.Context,
+	serialNumber string,
+	args *OverrideArgs,
+	mode BatteryMode,
+	window TimeWindow,
+) error {
+	if args.ChargeLimit > 0 {
+		if err := writeACChargeSetting(ctx, c, serialNumber, SettingNameChargeLimit, args.ChargeLimit); err != nil {
+			return err
+		}
+	}
+	if args.Charge {
+		if err := c.WriteChargeWindow(ctx, serialNumber, window); err != nil {
+			return err
+		}
+		if err := writeACChargeSetting(ctx, c, serialNumber, SettingNameChargeEnabled, true); err != nil {
+			return err
+		}
+	}
+	if args.Export {
+		if err := c.WriteDischargeWindow(ctx, serialNumber, window); err != nil {
+			return err
+		}
+	}
+	if mode != "" {
+		return c.SetBatteryMode(ctx, serialNumber, mode)
+	}
+	return nil
+}
+
+// restoreSnapshot writes back every setting of the snapshot, carrying on past
+// failures so that as much as possible is restored.
+func (c *Client) restoreSnapshot(ctx context.Context, snapshot *SettingsSnapshot) error {
+	serial := snapshot.InverterSerialNumber
+
+	var errs []error
+	if snapshot.EcoEnabled != nil {
+		discharge := snapshot.DischargeEnabled != nil && *snapshot.DischargeEnabled
+		errs = append(errs, c.writeBatteryToggles(ctx, serial, *snapshot.EcoEnabled, discharge))
+	}
+	if snapshot.BatteryPause != nil {
+		errs = append(errs, c.writeBatteryPause(ctx, serial, *snapshot.BatteryPause))
+	}
+	if snapshot.DischargeWindow != nil {
+		errs = append(errs, c.WriteDischargeWindow(ctx, serial, *snapshot.DischargeWindow))
+	}
+	if snapshot.ChargeWindow != nil {
+		errs = append(errs, c.WriteChargeWindow(ctx, serial, *snapshot.ChargeWindow))
+	}
+	if snapshot.ChargeEnabled != nil {
+		errs = append(errs, writeACChargeSetting(ctx, c, serial, SettingNameChargeEnabled, *snapshot.ChargeEnabled))
+	}
+	if snapshot.ChargeLimit != nil {
+		errs = append(errs, writeACChargeSetting(ctx, c, serial, SettingNameChargeLimit, *snapshot.ChargeLimit))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("inverter %s: restore settings: %w", serial, err)
+	}
+	return nil
+}
+
+// readACChargeSetting reads an AC charge setting, resolving its ID through
+// the catalog of the inverter.
+func readACChargeSetting[T any](ctx context.Context, c *Client, serialNumber string, name SettingName) (T, error) {
+	var zero T
+	if err := c.requireCapability(ctx, serialNumber, CapabilityACCharge); err != nil {
+		return zero, err
+	}
+
+	res, err := ReadSettingByName[T](ctx, c, serialNumber, name)
+	if err != nil {
+		return zero, err
+	}
+	return res.Data.Value, nil
+}
+
+func writeACChargeSetting[T any](ctx context.Context, c *Client, serialNumber string, name SettingName, value T) error {
+	if err := c.requireCapability(ctx, serialNumber, CapabilityACCharge); err != nil {
+		return err
+	}
+
+	_, err := WriteSettingByName(ctx, c, serialNumber, name, value)
+	return err
+}
+
+// restore writes the snapshot back and deletes it once every setting was
+// restored, so a failed restore can be retried with RecoverOverride.
+func (o *Override) restore(ctx context.Context) error {
+	if err := o.client.restoreSnapshot(ctx, o.snapshot); err != nil {
+		return err
+	}
+	return o.store.Delete(ctx, o.snapshot.InverterSerialNumber)
+}
+
+func (o *Override) wait(ctx context.Context, d time.Duration) {
+	defer close(o.done)
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	case <-o.stop:
+	}
+
+	o.err = o.restore(context.WithoutCancel(ctx))
+}
+
+func (o *Override) Snapshot() *SettingsSnapshot {
+	return o.snapshot
+}
+
+// Done is closed once the previous settings were restored or failed to.
+func (o *Override) Done() <-chan struct{} {
+	return o.done
+}
+
+// Err returns the restore error once Done is closed.
+func (o *Override) Err() error {
+	<-o.done
+	return o.err
+}
+
+// Stop ends the override early and waits for the previous settings to be
+// restored.
+func (o *Override) Stop() error {
+	o.stopOnce.Do(func() { close(o.stop) })
+	return o.Err()
+}
+
+// RecoverOverride restores the snapshot left in store by an override whose
+// process died before reverting it. It reports whether a snapshot was found.
+func (c *Client) RecoverOverride(ctx context.Context, serialNumber string, store SnapshotStore) (bool, error) {
+	snapshot, err := store.Load(ctx, serialNumber)
+	if err != nil || snapshot == nil {
+		return false, err
+	}
+
+	if err := c.restoreSnapshot(ctx, snapshot); err != nil {
+		return true, err
+	}
+	return true, store.Delete(ctx, serialNumber)
+}
@@ -0,0 +1,284 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+// overrideRoutes serves a catalog with the charge enable and limit settings
+// away from their default IDs, at 17 and 101.
+func overrideRoutes(routes ...*mockRoute) []*mockRoute {
+	return append([]*mockRoute{
+		{
+			URL:        fmt.Sprintf("%s/inverter/%s/settings", baseURL, "inverter-1"),
+			Path:       "testdata/list_settings_override_200.json",
+			StatusCode: http.StatusOK,
+		},
+	}, routes...)
+}
+
+func boostChargeRoutes() []*mockRoute {
+	return overrideRoutes(
+		readRoute("inverter-1", 64, "testdata/read_time_1600_200.json"),
+		readRoute("inverter-1", 65, "testdata/read_time_1900_200.json"),
+		readRoute("inverter-1", 17, "testdata/read_charge_disabled_200.json"),
+		readRoute("inverter-1", 101, "testdata/read_charge_limit_80_200.json"),
+		// override
+		writeRoute("inverter-1", 101, "testdata/write_charge_limit_200.json", `{"value":100}`),
+		writeRoute("inverter-1", 64, "testdata/write_charge_start_200.json", ""),
+		writeRoute("inverter-1", 65, "testdata/write_charge_end_200.json", ""),
+		writeRoute("inverter-1", 17, "testdata/write_charge_enabled_200.json", `{"value":true}`),
+		// restore
+		writeRoute("inverter-1", 64, "testdata/write_charge_start_200.json", `{"value":"16:00"}`),
+		writeRoute("inverter-1", 65, "testdata/write_charge_end_200.json", `{"value":"19:00"}`),
+		writeRoute("inverter-1", 17, "testdata/write_charge_enabled_200.json", `{"value":false}`),
+		writeRoute("inverter-1", 101, "testdata/write_charge_limit_80_200.json", `{"value":80}`),
+	)
+}
+
+func settingWriteURL(id int) string {
+	return fmt.Sprintf("%s/inverter/%s/settings/%d/write", baseURL, "inverter-1", id)
+}
+
+func TestClient_StartOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stop restores settings", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, boostChargeRoutes()...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		store := inverter.NewFileSnapshotStore(t.TempDir())
+		o, err := cl.StartOverride(
+			context.Background(),
+			&inverter.OverrideArgs{
+				InverterSerialNumber: "inverter-1",
+				Duration:             45 * time.Minute,
+				Charge:               true,
+				ChargeLimit:          100,
+			},
+			inverter.WithSnapshotStore(store),
+		)
+		require.NoError(t, err)
+		require.Equal(t, inverter.MustTimeWindow("16:00", "19:00"), *o.Snapshot().ChargeWindow)
+		require.False(t, *o.Snapshot().ChargeEnabled)
+		require.Equal(t, 80, *o.Snapshot().ChargeLimit)
+		require.Nil(t, o.Snapshot().EcoEnabled)
+		require.FileExists(t, filepath.Join(store.Dir, "override-inverter-1.json"))
+
+		require.NoError(t, o.Stop())
+		for _, id := range []int{17, 64, 65, 101} {
+			require.Equal(t, 2, rrt.Calls(settingWriteURL(id)))
+		}
+		require.NoFileExists(t, filepath.Join(store.Dir, "override-inverter-1.json"))
+	})
+
+	t.Run("context cancel restores settings", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, boostChargeRoutes()...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		o, err := cl.StartOverride(ctx, &inverter.OverrideArgs{
+			InverterSerialNumber: "inverter-1",
+			Duration:             time.Hour,
+			Charge:               true,
+			ChargeLimit:          100,
+		})
+		require.NoError(t, err)
+
+		cancel()
+		select {
+		case <-o.Done():
+		case <-time.After(time.Second):
+			t.Fatal("override not restored")
+		}
+		require.NoError(t, o.Err())
+		require.Equal(t, 2, rrt.Calls(settingWriteURL(101)))
+	})
+
+	t.Run("restores paused battery", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, batteryModeRoutes(
+			readRoute("inverter-1", 53, "testdata/read_discharge_start_200.json"),
+			readRoute("inverter-1", 54, "testdata/read_discharge_end_200.json"),
+			readRoute("inverter-1", 24, "testdata/read_eco_mode_disabled_200.json"),
+			readRoute("inverter-1", 56, "testdata/read_discharge_disabled_200.json"),
+			readRoute("inverter-1", 96, "testdata/read_pause_battery_1_200.json"),
+			// override
+			writeRoute("inverter-1", 53, "testdata/write_discharge_start_200.json", ""),
+			writeRoute("inverter-1", 54, "testdata/write_discharge_end_200.json", ""),
+			writeRoute("inverter-1", 24, "testdata/write_eco_mode_disabled_200.json", `{"value":false}`),
+			writeRoute("inverter-1", 56, "testdata/write_discharge_enabled_200.json", `{"value":true}`),
+			writeRoute("inverter-1", 96, "testdata/write_pause_battery_0_200.json", `{"value":0}`),
+			// restore
+			writeRoute("inverter-1", 53, "testdata/write_discharge_start_200.json", `{"value":"01:00"}`),
+			writeRoute("inverter-1", 54, "testdata/write_discharge_end_200.json", `{"value":"01:00"}`),
+			writeRoute("inverter-1", 56, "testdata/write_discharge_disabled_200.json", `{"value":false}`),
+			writeRoute("inverter-1", 96, "testdata/write_pause_battery_1_200.json", `{"value":1}`),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		o, err := cl.StartOverride(context.Background(), &inverter.OverrideArgs{
+			InverterSerialNumber: "inverter-1",
+			Duration:             30 * time.Minute,
+			Export:               true,
+		})
+		require.NoError(t, err)
+		require.False(t, *o.Snapshot().EcoEnabled)
+		require.False(t, *o.Snapshot().DischargeEnabled)
+		require.Equal(t, inverter.BatteryPauseCharge, *o.Snapshot().BatteryPause)
+
+		require.NoError(t, o.Stop())
+		for _, id := range []int{24, 53, 54, 56, 96} {
+			require.Equal(t, 2, rrt.Calls(settingWriteURL(id)))
+		}
+	})
+
+	t.Run("charge limit only", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, overrideRoutes(
+			readRoute("inverter-1", 101, "testdata/read_charge_limit_80_200.json"),
+			writeRoute("inverter-1", 101, "testdata/write_charge_limit_200.json", `{"value":100}`),
+			writeRoute("inverter-1", 101, "testdata/write_charge_limit_80_200.json", `{"value":80}`),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		args := &inverter.OverrideArgs{
+			InverterSerialNumber: "inverter-1",
+			Duration:             time.Hour,
+			ChargeLimit:          100,
+		}
+		o, err := cl.StartOverride(context.Background(), args)
+		require.NoError(t, err)
+		require.Equal(t, 80, *o.Snapshot().ChargeLimit)
+
+		_, err = cl.StartOverride(context.Background(), args)
+		require.ErrorIs(t, err, inverter.ErrOverrideActive)
+
+		require.NoError(t, o.Stop())
+		require.Equal(t, 2, rrt.Calls(settingWriteURL(101)))
+	})
+
+	t.Run("no setting changed", func(t *testing.T) {
+		t.Parallel()
+
+		cl := inverter.NewClient(testToken)
+
+		_, err := cl.StartOverride(context.Background(), &inverter.OverrideArgs{
+			InverterSerialNumber: "inverter-1",
+			Duration:             time.Hour,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("charge and export", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, overrideRoutes()...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		_, err := cl.StartOverride(context.Background(), &inverter.OverrideArgs{
+			InverterSerialNumber: "inverter-1",
+			Duration:             time.Hour,
+			Charge:               true,
+			Export:               true,
+		})
+		require.Error(t, err)
+		require.Equal(t, 0, rrt.Calls(fmt.Sprintf("%s/inverter/%s/settings", baseURL, "inverter-1")))
+	})
+
+	t.Run("already active", func(t *testing.T) {
+		t.Parallel()
+
+		cl := inverter.NewClient(testToken)
+
+		store := inverter.NewMemorySnapshotStore()
+		require.NoError(t, store.Save(context.Background(), "inverter-1", &inverter.SettingsSnapshot{
+			InverterSerialNumber: "inverter-1",
+			Until:                time.Now().Add(time.Hour),
+		}))
+
+		_, err := cl.StartOverride(
+			context.Background(),
+			&inverter.OverrideArgs{
+				InverterSerialNumber: "inverter-1",
+				Duration:             time.Hour,
+				Export:               true,
+			},
+			inverter.WithSnapshotStore(store),
+		)
+		require.ErrorIs(t, err, inverter.ErrOverrideActive)
+	})
+}
+
+func TestClient_RecoverOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, overrideRoutes(
+			writeRoute("inverter-1", 101, "testdata/write_charge_limit_80_200.json", `{"value":80}`),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		dir := t.TempDir()
+		b, err := os.ReadFile("testdata/override_snapshot.json")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "override-inverter-1.json"), b, 0o600))
+
+		store := inverter.NewFileSnapshotStore(dir)
+		recovered, err := cl.RecoverOverride(context.Background(), "inverter-1", store)
+		require.NoError(t, err)
+		require.True(t, recovered)
+		require.Equal(t, 1, rrt.Calls(settingWriteURL(101)))
+
+		snapshot, err := store.Load(context.Background(), "inverter-1")
+		require.NoError(t, err)
+		require.Nil(t, snapshot)
+
+		recovered, err = cl.RecoverOverride(context.Background(), "inverter-1", store)
+		require.NoError(t, err)
+		require.False(t, recovered)
+	})
+}
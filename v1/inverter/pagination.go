@@ -0,0 +1,49 @@
+package inverter
+
+import (
+	"net/url"
+	"strconv"
+)
+
+type PageLinks struct {
+	First string  `json:"first"`
+	Last  string  `json:"last"`
+	Prev  *string `json:"prev"`
+	Next  *string `json:"next"`
+}
+
+type PageMeta struct {
+	CurrentPage int    `json:"current_page"`
+	From        int    `json:"from"`
+	LastPage    int    `json:"last_page"`
+	Path        string `json:"path"`
+	PerPage     int    `json:"per_page"`
+	To          int    `json:"to"`
+	Total       int    `json:"total"`
+}
+
+// HasNext reports whether another page follows the one described by m.
+func (m *PageMeta) HasNext() bool {
+	return m.CurrentPage < m.LastPage
+}
+
+// pageValues returns the query selecting a page, leaving out nil parameters.
+func pageValues(page, pageSize *int) url.Values {
+	q := url.Values{}
+	if page != nil {
+		q.Set("page", strconv.Itoa(*page))
+	}
+	if pageSize != nil {
+		q.Set("pageSize", strconv.Itoa(*pageSize))
+	}
+	return q
+}
+
+// pageQuery returns the query string selecting a page, empty when both are nil.
+func pageQuery(page, pageSize *int) string {
+	q := pageValues(page, pageSize)
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
@@ -0,0 +1,101 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	fmtPresets = "%s/inverter/%s/presets"
+	fmtPreset  = "%s/inverter/%s/presets/%s"
+)
+
+type PresetParameter struct {
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	Description     string   `json:"description"`
+	Validation      string   `json:"validation"`
+	ValidationRules []string `json:"validation_rules"`
+}
+
+// Preset changes several settings of the inverter in a single operation.
+type Preset struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Parameters  []*PresetParameter `json:"parameters"`
+}
+
+type ListPresetsArgs struct {
+	InverterSerialNumber string
+}
+
+type ListPresetsResponse struct {
+	Data []*Preset `json:"data"`
+}
+
+func (c *Client) ListPresets(ctx context.Context, args *ListPresetsArgs) (*ListPresetsResponse, error) {
+	u := fmt.Sprintf(fmtPresets, c.baseURL, args.InverterSerialNumber)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(ListPresetsResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type PresetArgs struct {
+	InverterSerialNumber string
+	PresetID             string
+}
+
+type PresetResponse struct {
+	Data *Preset `json:"data"`
+}
+
+// Preset reads a preset and the parameters it accepts.
+func (c *Client) Preset(ctx context.Context, args *PresetArgs) (*PresetResponse, error) {
+	u := fmt.Sprintf(fmtPreset, c.baseURL, args.InverterSerialNumber, args.PresetID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(PresetResponse)
+	if err := c.do(req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ApplyPresetArgs holds the preset parameters as T, which is sent as the
+// JSON body.
+type ApplyPresetArgs[T any] struct {
+	InverterSerialNumber string
+	PresetID             string
+	Parameters           T
+}
+
+func NewApplyPresetArgs[T any](serialNumber string, presetID string, parameters T) *ApplyPresetArgs[T] {
+	return &ApplyPresetArgs[T]{
+		InverterSerialNumber: serialNumber,
+		PresetID:             presetID,
+		Parameters:           parameters,
+	}
+}
+
+type ApplyPresetResponse = CommandResponse
+
+// ApplyPreset applies a preset. WithStrictWrites turns an unconfirmed preset
+// into an *UnconfirmedError.
+func ApplyPreset[T any](ctx context.Context, c *Client, args *ApplyPresetArgs[T]) (*ApplyPresetResponse, error) {
+	u := fmt.Sprintf(fmtPreset, c.baseURL, args.InverterSerialNumber, args.PresetID)
+	return c.postOperation(ctx, u, args.InverterSerialNumber, "preset "+args.PresetID, args.Parameters)
+}
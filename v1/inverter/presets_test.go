@@ -0,0 +1,128 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+type timedChargeParameters struct {
+	StartTime inverter.TimeOfDay `json:"start_time"`
+	EndTime   inverter.TimeOfDay `json:"end_time"`
+	TargetSOC int                `json:"target_soc"`
+}
+
+func TestClient_ListPresets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/list_presets_200.json",
+			http.StatusOK,
+			fmt.Sprintf("%s/inverter/%s/presets", baseURL, "inverter-1"),
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.ListPresets(context.Background(), &inverter.ListPresetsArgs{InverterSerialNumber: "inverter-1"})
+		require.NoError(t, err)
+		require.Len(t, data.Data, 2)
+		require.Equal(t, "eco", data.Data[0].ID)
+		require.Empty(t, data.Data[0].Parameters)
+		require.Equal(t, "timed-charge", data.Data[1].ID)
+		require.Len(t, data.Data[1].Parameters, 3)
+	})
+}
+
+func TestClient_Preset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(
+			t,
+			"testdata/preset_200.json",
+			http.StatusOK,
+			fmt.Sprintf("%s/inverter/%s/presets/%s", baseURL, "inverter-1", "timed-charge"),
+			"",
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := cl.Preset(context.Background(), &inverter.PresetArgs{
+			InverterSerialNumber: "inverter-1",
+			PresetID:             "timed-charge",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Timed Charge", data.Data.Name)
+		require.Equal(t, &inverter.PresetParameter{
+			Name:            "target_soc",
+			Type:            "integer",
+			Description:     "Battery percent to charge up to",
+			Validation:      "Value must be between 4 and 100",
+			ValidationRules: []string{"integer", "between:4,100"},
+		}, data.Data.Parameters[2])
+	})
+}
+
+func TestApplyPreset(t *testing.T) {
+	t.Parallel()
+
+	args := inverter.NewApplyPresetArgs("inverter-1", "timed-charge", &timedChargeParameters{
+		StartTime: inverter.MustParseTimeOfDay("00:30"),
+		EndTime:   inverter.MustParseTimeOfDay("04:30"),
+		TargetSOC: 100,
+	})
+	testURL := fmt.Sprintf("%s/inverter/%s/presets/%s", baseURL, args.InverterSerialNumber, args.PresetID)
+	expectedBody := `{"start_time":"00:30","end_time":"04:30","target_soc":100}`
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(t, "testdata/apply_preset_200.json", http.StatusOK, testURL, expectedBody)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		data, err := inverter.ApplyPreset(context.Background(), cl, args)
+		require.NoError(t, err)
+		require.True(t, data.Data.Success)
+		require.Equal(t, "Preset applied", data.Data.Message)
+	})
+
+	t.Run("strict not confirmed", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient := newMockClient(t, "testdata/apply_preset_failed_200.json", http.StatusOK, testURL, expectedBody)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithStrictWrites(),
+		)
+
+		_, err := inverter.ApplyPreset(context.Background(), cl, args)
+		var unconfirmedErr *inverter.UnconfirmedError
+		require.ErrorAs(t, err, &unconfirmedErr)
+		require.ErrorIs(t, err, inverter.ErrWriteRejected)
+		require.Equal(t, "preset timed-charge", unconfirmedErr.Operation)
+	})
+}
@@ -0,0 +1,120 @@
+package inverter
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request made through the
+// clients it is attached to. It is safe for concurrent use, so one limiter
+// can be shared by several clients using the same API token.
+type RateLimiter struct {
+	mu           sync.Mutex
+	maxRate      float64
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter allows perSecond requests on average with bursts of up to
+// burst requests. A perSecond of zero or less sets no limit of its own, the
+// limiter then only pauses for the headers passed to Observe.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		maxRate: perSecond,
+		rate:    perSecond,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		d := l.reserve(time.Now())
+		if d <= 0 {
+			return nil
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait before
+// trying again.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if l.maxRate <= 0 {
+		return 0
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+	l.tokens += elapsed * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// Observe adapts the limiter to the rate limit headers of a response:
+// X-RateLimit-Limit (requests per minute) lowers the rate, an exhausted
+// X-RateLimit-Remaining or a Retry-After pauses requests until the window
+// resets.
+func (l *RateLimiter) Observe(h http.Header) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil && limit > 0 && l.maxRate > 0 {
+		l.rate = min(l.maxRate, float64(limit)/60)
+	}
+
+	if remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		l.tokens = min(l.tokens, float64(remaining))
+		if remaining <= 0 {
+			l.blockUntil(now.Add(time.Minute / time.Duration(max(1, int(l.rate*60)))))
+			if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				l.blockUntil(time.Unix(reset, 0))
+			}
+		}
+	}
+
+	if d, ok := parseRetryAfter(h.Get("Retry-After")); ok {
+		l.blockUntil(now.Add(d))
+	}
+}
+
+func (l *RateLimiter) blockUntil(t time.Time) {
+	if t.After(l.blockedUntil) {
+		l.blockedUntil = t
+	}
+}
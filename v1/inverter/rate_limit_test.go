@@ -0,0 +1,140 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("burst then rate", func(t *testing.T) {
+		t.Parallel()
+
+		l := inverter.NewRateLimiter(50, 2)
+		start := time.Now()
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, l.Wait(context.Background()))
+			}()
+		}
+		wg.Wait()
+
+		require.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		t.Parallel()
+
+		for _, perSecond := range []float64{0, -1} {
+			l := inverter.NewRateLimiter(perSecond, 1)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			for range 10 {
+				require.NoError(t, l.Wait(ctx))
+			}
+			cancel()
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		l := inverter.NewRateLimiter(0.01, 1)
+		require.NoError(t, l.Wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retry after", func(t *testing.T) {
+		t.Parallel()
+
+		l := inverter.NewRateLimiter(100, 10)
+		l.Observe(http.Header{"Retry-After": []string{"1"}})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("remaining exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		l := inverter.NewRateLimiter(100, 10)
+		l.Observe(http.Header{
+			"X-Ratelimit-Limit":     []string{"60"},
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("remaining available", func(t *testing.T) {
+		t.Parallel()
+
+		l := inverter.NewRateLimiter(100, 10)
+		l.Observe(http.Header{
+			"X-Ratelimit-Limit":     []string{"6000"},
+			"X-Ratelimit-Remaining": []string{"5"},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.NoError(t, l.Wait(ctx))
+	})
+}
+
+func TestClient_WithRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate limited response pauses shared clients", func(t *testing.T) {
+		t.Parallel()
+
+		testURL := fmt.Sprintf("%s/inverter/%s/system-data/latest", baseURL, "inverter-1")
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        testURL,
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"60"}},
+			},
+		)
+
+		l := inverter.NewRateLimiter(100, 10)
+		cl1 := inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient), inverter.WithRateLimiter(l))
+		cl2 := inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient), inverter.WithRateLimiter(l))
+
+		args := &inverter.SystemDataLatestArgs{InverterSerialNumber: "inverter-1"}
+		_, err := cl1.SystemDataLatest(context.Background(), args)
+		require.ErrorIs(t, err, inverter.ErrRateLimited)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = cl2.SystemDataLatest(ctx, args)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 1, rrt.Calls(testURL))
+	})
+}
@@ -0,0 +1,184 @@
+package inverter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Reads are always
+// eligible, writes only when RetryWrites is set since a write that timed out
+// may still have reached the inverter.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	// MaxDelay caps the backoff. A Retry-After asking for a longer wait is
+	// not retried, the response is returned instead.
+	MaxDelay    time.Duration
+	RetryWrites bool
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+type idempotentKey struct{}
+
+// markIdempotent flags a non GET request as safe to retry.
+func markIdempotent(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), idempotentKey{}, true))
+}
+
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	if ok, _ := req.Context().Value(idempotentKey{}).(bool); ok {
+		return true
+	}
+	return p.RetryWrites
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil && transient(err)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// transient reports whether a transport error may go away on retry: timeouts,
+// resets and refused or dropped connections. TLS, URL and other errors are
+// returned straight away.
+func transient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// delay returns the wait before the given retry, preferring the server's
+// Retry-After over the jittered exponential backoff. It reports false when
+// Retry-After asks for longer than MaxDelay.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d, p.MaxDelay <= 0 || d <= p.MaxDelay
+		}
+	}
+
+	d := p.BaseDelay << (attempt - 1)
+	if p.MaxDelay > 0 && (d > p.MaxDelay || d <= 0) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0, true
+	}
+	return d/2 + rand.N(d/2+1), true
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if !c.retry.allows(req) {
+		return c.roundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.roundTrip(req)
+		if attempt >= c.retry.MaxAttempts || !c.retry.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay, ok := c.retry.delay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// roundTrip sends a single attempt, waiting on the rate limiter first.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.limiter == nil {
+		return c.httpCl.Do(req)
+	}
+
+	if err := c.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := c.httpCl.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.limiter.Observe(resp.Header)
+	return resp, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
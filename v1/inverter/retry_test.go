@@ -0,0 +1,280 @@
+package inverter_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func testRetryPolicy() *inverter.RetryPolicy {
+	return &inverter.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+}
+
+func TestClient_RetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	systemDataURL := fmt.Sprintf("%s/inverter/%s/system-data/latest", baseURL, "inverter-1")
+	readURL := fmt.Sprintf("%s/inverter/%s/settings/%s/read", baseURL, "inverter-1", inverter.DefaultSettingChargeLimit)
+	writeURL := fmt.Sprintf("%s/inverter/%s/settings/%s/write", baseURL, "inverter-1", inverter.DefaultSettingChargeLimit)
+
+	t.Run("read is retried on server error", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{URL: systemDataURL, StatusCode: http.StatusBadGateway},
+			&mockRoute{URL: systemDataURL, Path: "testdata/system_data_latest_200.json", StatusCode: http.StatusOK},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(testRetryPolicy()),
+		)
+
+		data, err := cl.SystemDataLatest(context.Background(), &inverter.SystemDataLatestArgs{
+			InverterSerialNumber: "inverter-1",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 96, data.Data.Battery.Percent)
+		require.Equal(t, 2, rrt.Calls(systemDataURL))
+	})
+
+	t.Run("setting read is retried on rate limit", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        readURL,
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"0"}},
+			},
+			&mockRoute{URL: readURL, Path: "testdata/read_charge_limit_200.json", StatusCode: http.StatusOK},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(testRetryPolicy()),
+		)
+
+		data, err := cl.ReadSettingChargeLimit(
+			context.Background(),
+			inverter.NewReadSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 100, data.Data.Value)
+		require.Equal(t, 2, rrt.Calls(readURL))
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{URL: systemDataURL, StatusCode: http.StatusServiceUnavailable},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(testRetryPolicy()),
+		)
+
+		_, err := cl.SystemDataLatest(context.Background(), &inverter.SystemDataLatestArgs{
+			InverterSerialNumber: "inverter-1",
+		})
+		require.ErrorIs(t, err, inverter.ErrServer)
+		require.Equal(t, 3, rrt.Calls(systemDataURL))
+	})
+
+	t.Run("client error is not retried", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{URL: systemDataURL, Path: "testdata/unauthenticated_401.json", StatusCode: http.StatusUnauthorized},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(testRetryPolicy()),
+		)
+
+		_, err := cl.SystemDataLatest(context.Background(), &inverter.SystemDataLatestArgs{
+			InverterSerialNumber: "inverter-1",
+		})
+		require.ErrorIs(t, err, inverter.ErrUnauthorized)
+		require.Equal(t, 1, rrt.Calls(systemDataURL))
+	})
+
+	t.Run("write is not retried by default", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{URL: writeURL, StatusCode: http.StatusBadGateway},
+			&mockRoute{URL: writeURL, Path: "testdata/write_charge_limit_200.json", StatusCode: http.StatusOK},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(testRetryPolicy()),
+		)
+
+		_, err := cl.WriteSettingChargeLimit(
+			context.Background(),
+			inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 100),
+		)
+		require.ErrorIs(t, err, inverter.ErrServer)
+		require.Equal(t, 1, rrt.Calls(writeURL))
+	})
+
+	t.Run("write is retried when enabled", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{URL: writeURL, StatusCode: http.StatusBadGateway, ExpectedBodyJSON: `{"value":100}`},
+			&mockRoute{
+				URL:              writeURL,
+				Path:             "testdata/write_charge_limit_200.json",
+				StatusCode:       http.StatusOK,
+				ExpectedBodyJSON: `{"value":100}`,
+			},
+		)
+
+		policy := testRetryPolicy()
+		policy.RetryWrites = true
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(policy),
+		)
+
+		data, err := cl.WriteSettingChargeLimit(
+			context.Background(),
+			inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 100),
+		)
+		require.NoError(t, err)
+		require.True(t, data.Data.Success)
+		require.Equal(t, 2, rrt.Calls(writeURL))
+	})
+
+	t.Run("stops on context cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        systemDataURL,
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"60"}},
+			},
+		)
+
+		policy := testRetryPolicy()
+		policy.MaxDelay = 0
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(policy),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := cl.SystemDataLatest(ctx, &inverter.SystemDataLatestArgs{
+			InverterSerialNumber: "inverter-1",
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 1, rrt.Calls(systemDataURL))
+	})
+	t.Run("retry after beyond max delay is not waited for", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        systemDataURL,
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"3600"}},
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithRetryPolicy(testRetryPolicy()),
+		)
+
+		_, err := cl.SystemDataLatest(context.Background(), &inverter.SystemDataLatestArgs{
+			InverterSerialNumber: "inverter-1",
+		})
+		require.ErrorIs(t, err, inverter.ErrRateLimited)
+		require.Equal(t, 1, rrt.Calls(systemDataURL))
+	})
+
+	t.Run("only transient network errors are retried", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name  string
+			err   error
+			calls int
+		}{
+			{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, calls: 3},
+			{name: "unexpected eof", err: io.ErrUnexpectedEOF, calls: 3},
+			{name: "tls", err: x509.UnknownAuthorityError{}, calls: 1},
+			{name: "other", err: errors.New("unsupported protocol scheme"), calls: 1},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var calls atomic.Int32
+				cl := inverter.NewClient(
+					testToken,
+					inverter.WithHTTPClient(&http.Client{
+						Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+							calls.Add(1)
+							return nil, tt.err
+						}),
+					}),
+					inverter.WithRetryPolicy(testRetryPolicy()),
+				)
+
+				_, err := cl.SystemDataLatest(context.Background(), &inverter.SystemDataLatestArgs{
+					InverterSerialNumber: "inverter-1",
+				})
+				require.Error(t, err)
+				require.Equal(t, int32(tt.calls), calls.Load())
+			})
+		}
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,253 @@
+package inverter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type ScheduleKind int
+
+const (
+	ScheduleCharge ScheduleKind = iota
+	ScheduleDischarge
+)
+
+func (k ScheduleKind) String() string {
+	switch k {
+	case ScheduleCharge:
+		return "AC charge"
+	case ScheduleDischarge:
+		return "DC discharge"
+	default:
+		return "unknown"
+	}
+}
+
+type ScheduleSlot struct {
+	Window TimeWindow
+	// SoC is the battery percent target of the slot: the upper limit when
+	// charging, the lower limit when discharging. Nil leaves it unchanged.
+	SoC *int
+}
+
+// Schedule is an ordered list of charge or discharge windows.
+type Schedule struct {
+	Kind  ScheduleKind
+	Slots []*ScheduleSlot
+}
+
+type scheduleSlotSettings struct {
+	start, end, soc string
+}
+
+func scheduleSlotNames(kind ScheduleKind, n int) (start, end, soc SettingName) {
+	switch kind {
+	case ScheduleDischarge:
+		if n == 1 {
+			start, end = SettingNameDischargeStart, SettingNameDischargeEnd
+		} else {
+			start = SettingName(fmt.Sprintf("DC Discharge %d Start Time", n))
+			end = SettingName(fmt.Sprintf("DC Discharge %d End Time", n))
+		}
+		soc = SettingName(fmt.Sprintf("DC Discharge %d Lower SOC %% Limit", n))
+	default:
+		if n == 1 {
+			start, end = SettingNameChargeStart, SettingNameChargeEnd
+		} else {
+			start = SettingName(fmt.Sprintf("AC Charge %d Start Time", n))
+			end = SettingName(fmt.Sprintf("AC Charge %d End Time", n))
+		}
+		soc = SettingName(fmt.Sprintf("AC Charge %d Upper SOC %% Limit", n))
+	}
+	return start, end, soc
+}
+
+// scheduleSlots returns the setting IDs of every slot the inverter exposes.
+// soc is empty for slots without a per slot target.
+func (c *Client) scheduleSlots(ctx context.Context, serialNumber string, kind ScheduleKind) ([]*scheduleSlotSettings, error) {
+	capability := CapabilityACCharge
+	if kind == ScheduleDischarge {
+		capability = CapabilityDCDischarge
+	}
+	if err := c.requireCapability(ctx, serialNumber, capability); err != nil {
+		return nil, err
+	}
+
+	cat, err := c.SettingCatalog(ctx, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []*scheduleSlotSettings
+	for n := 1; n <= maxScheduleSlots; n++ {
+		startName, endName, socName := scheduleSlotNames(kind, n)
+
+		start, err := cat.Lookup(startName)
+		if errors.Is(err, ErrSettingNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end, err := cat.Lookup(endName)
+		if err != nil {
+			return nil, err
+		}
+
+		slot := &scheduleSlotSettings{start: start.ID, end: end.ID}
+		soc, err := cat.Lookup(socName)
+		switch {
+		case err == nil:
+			slot.soc = soc.ID
+		case !errors.Is(err, ErrSettingNotFound):
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
+
+// ReadSchedule reads every enabled slot of the charge or discharge schedule.
+// Slots starting and ending at the same time are disabled and left out.
+func (c *Client) ReadSchedule(ctx context.Context, serialNumber string, kind ScheduleKind) (*Schedule, error) {
+	slots, err := c.scheduleSlots(ctx, serialNumber, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &Schedule{Kind: kind}
+	for _, slot := range slots {
+		start, err := ReadSetting[TimeOfDay](ctx, c, NewReadSettingArgs(serialNumber, slot.start))
+		if err != nil {
+			return nil, err
+		}
+		end, err := ReadSetting[TimeOfDay](ctx, c, NewReadSettingArgs(serialNumber, slot.end))
+		if err != nil {
+			return nil, err
+		}
+		window := TimeWindow{Start: start.Data.Value, End: end.Data.Value}
+		if window.Disabled() {
+			continue
+		}
+
+		s := &ScheduleSlot{Window: window}
+		if slot.soc != "" {
+			soc, err := ReadSetting[int](ctx, c, NewReadSettingArgs(serialNumber, slot.soc))
+			if err != nil {
+				return nil, err
+			}
+			s.SoC = &soc.Data.Value
+		}
+		schedule.Slots = append(schedule.Slots, s)
+	}
+
+	return schedule, nil
+}
+
+// WriteSchedule writes the schedule slots in order and disables the slots
+// left over. Writes are not atomic: on error the inverter may be left with
+// part of the new schedule.
+func (c *Client) WriteSchedule(ctx context.Context, serialNumber string, schedule *Schedule) error {
+	slots, err := c.scheduleSlots(ctx, serialNumber, schedule.Kind)
+	if err != nil {
+		return err
+	}
+	if len(schedule.Slots) > len(slots) {
+		return fmt.Errorf(
+			"inverter %s: %d %s slots requested, %d available: %w",
+			serialNumber,
+			len(schedule.Slots),
+			schedule.Kind,
+			len(slots),
+			ErrUnsupported,
+		)
+	}
+	for i, s := range schedule.Slots {
+		if s.SoC != nil && slots[i].soc == "" {
+			return fmt.Errorf("inverter %s: %s slot %d SoC target: %w", serialNumber, schedule.Kind, i+1, ErrUnsupported)
+		}
+	}
+
+	for i, slot := range slots {
+		var (
+			window TimeWindow
+			soc    *int
+		)
+		if i < len(schedule.Slots) {
+			window, soc = schedule.Slots[i].Window, schedule.Slots[i].SoC
+		}
+
+		if _, err := WriteSetting(ctx, c, NewWriteSettingArgs(serialNumber, slot.start, window.Start)); err != nil {
+			return err
+		}
+		if _, err := WriteSetting(ctx, c, NewWriteSettingArgs(serialNumber, slot.end, window.End)); err != nil {
+			return err
+		}
+		if soc != nil {
+			if _, err := WriteSetting(ctx, c, NewWriteSettingArgs(serialNumber, slot.soc, *soc)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) ReadChargeWindow(ctx context.Context, serialNumber string) (TimeWindow, error) {
+	return c.readWindow(ctx, serialNumber, CapabilityACCharge, SettingNameChargeStart, SettingNameChargeEnd)
+}
+
+func (c *Client) WriteChargeWindow(ctx context.Context, serialNumber string, window TimeWindow) error {
+	return c.writeWindow(ctx, serialNumber, CapabilityACCharge, SettingNameChargeStart, SettingNameChargeEnd, window)
+}
+
+func (c *Client) ReadDischargeWindow(ctx context.Context, serialNumber string) (TimeWindow, error) {
+	return c.readWindow(ctx, serialNumber, CapabilityDCDischarge, SettingNameDischargeStart, SettingNameDischargeEnd)
+}
+
+func (c *Client) WriteDischargeWindow(ctx context.Context, serialNumber string, window TimeWindow) error {
+	return c.writeWindow(ctx, serialNumber, CapabilityDCDischarge, SettingNameDischargeStart, SettingNameDischargeEnd, window)
+}
+
+// readWindow reads the first slot of a schedule, resolving the setting IDs
+// through the catalog of the inverter.
+func (c *Client) readWindow(
+	ctx context.Context,
+	serialNumber string,
+	capability Capability,
+	startName, endName SettingName,
+) (TimeWindow, error) {
+	if err := c.requireCapability(ctx, serialNumber, capability); err != nil {
+		return TimeWindow{}, err
+	}
+
+	start, err := ReadSettingByName[TimeOfDay](ctx, c, serialNumber, startName)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	end, err := ReadSettingByName[TimeOfDay](ctx, c, serialNumber, endName)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	return TimeWindow{Start: start.Data.Value, End: end.Data.Value}, nil
+}
+
+func (c *Client) writeWindow(
+	ctx context.Context,
+	serialNumber string,
+	capability Capability,
+	startName, endName SettingName,
+	window TimeWindow,
+) error {
+	if err := c.requireCapability(ctx, serialNumber, capability); err != nil {
+		return err
+	}
+
+	if _, err := WriteSettingByName(ctx, c, serialNumber, startName, window.Start); err != nil {
+		return err
+	}
+	_, err := WriteSettingByName(ctx, c, serialNumber, endName, window.End)
+	return err
+}
@@ -0,0 +1,194 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func scheduleRoutes(serial string, routes ...*mockRoute) []*mockRoute {
+	return append([]*mockRoute{
+		{
+			URL:        fmt.Sprintf("%s/inverter/%s/settings", baseURL, serial),
+			Path:       "testdata/list_settings_schedule_200.json",
+			StatusCode: http.StatusOK,
+		},
+	}, routes...)
+}
+
+func readRoute(serial string, id int, path string) *mockRoute {
+	return &mockRoute{
+		URL:        fmt.Sprintf("%s/inverter/%s/settings/%d/read", baseURL, serial, id),
+		Path:       path,
+		StatusCode: http.StatusOK,
+	}
+}
+
+func writeRoute(serial string, id int, path, expectedBody string) *mockRoute {
+	return &mockRoute{
+		URL:              fmt.Sprintf("%s/inverter/%s/settings/%d/write", baseURL, serial, id),
+		Path:             path,
+		StatusCode:       http.StatusOK,
+		ExpectedBodyJSON: expectedBody,
+	}
+}
+
+func TestClient_ReadSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("charge", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(t, scheduleRoutes(
+			"inverter-1",
+			readRoute("inverter-1", 64, "testdata/read_time_1600_200.json"),
+			readRoute("inverter-1", 65, "testdata/read_time_1900_200.json"),
+			readRoute("inverter-1", 101, "testdata/read_charge_limit_80_200.json"),
+			readRoute("inverter-1", 31, "testdata/read_time_0000_200.json"),
+			readRoute("inverter-1", 32, "testdata/read_time_0000_200.json"),
+			readRoute("inverter-1", 33, "testdata/read_charge_start_200.json"),
+			readRoute("inverter-1", 34, "testdata/read_time_0000_200.json"),
+			readRoute("inverter-1", 103, "testdata/read_charge_limit_50_200.json"),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		schedule, err := cl.ReadSchedule(context.Background(), "inverter-1", inverter.ScheduleCharge)
+		require.NoError(t, err)
+		require.Equal(t, &inverter.Schedule{
+			Kind: inverter.ScheduleCharge,
+			Slots: []*inverter.ScheduleSlot{
+				{Window: inverter.MustTimeWindow("16:00", "19:00"), SoC: ptr(80)},
+				{Window: inverter.MustTimeWindow("01:00", "00:00"), SoC: ptr(50)},
+			},
+		}, schedule)
+	})
+
+	t.Run("discharge without soc", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(t, scheduleRoutes(
+			"inverter-1",
+			readRoute("inverter-1", 53, "testdata/read_time_1600_200.json"),
+			readRoute("inverter-1", 54, "testdata/read_time_1900_200.json"),
+			readRoute("inverter-1", 41, "testdata/read_time_0000_200.json"),
+			readRoute("inverter-1", 42, "testdata/read_time_0000_200.json"),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		schedule, err := cl.ReadSchedule(context.Background(), "inverter-1", inverter.ScheduleDischarge)
+		require.NoError(t, err)
+		require.Equal(t, &inverter.Schedule{
+			Kind:  inverter.ScheduleDischarge,
+			Slots: []*inverter.ScheduleSlot{{Window: inverter.MustTimeWindow("16:00", "19:00")}},
+		}, schedule)
+	})
+
+	t.Run("same start and end is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(t, scheduleRoutes(
+			"inverter-1",
+			readRoute("inverter-1", 53, "testdata/read_time_1600_200.json"),
+			readRoute("inverter-1", 54, "testdata/read_time_1900_200.json"),
+			readRoute("inverter-1", 41, "testdata/read_time_1900_200.json"),
+			readRoute("inverter-1", 42, "testdata/read_time_1900_200.json"),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		schedule, err := cl.ReadSchedule(context.Background(), "inverter-1", inverter.ScheduleDischarge)
+		require.NoError(t, err)
+		require.Len(t, schedule.Slots, 1)
+		require.Equal(t, inverter.MustTimeWindow("16:00", "19:00"), schedule.Slots[0].Window)
+	})
+}
+
+func TestClient_WriteSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(t, scheduleRoutes(
+			"inverter-1",
+			writeRoute("inverter-1", 64, "testdata/write_charge_start_200.json", `{"value":"16:00"}`),
+			writeRoute("inverter-1", 65, "testdata/write_charge_end_200.json", `{"value":"19:00"}`),
+			writeRoute("inverter-1", 101, "testdata/write_charge_limit_80_200.json", `{"value":80}`),
+			writeRoute("inverter-1", 31, "testdata/write_time_0000_200.json", `{"value":"01:00"}`),
+			writeRoute("inverter-1", 32, "testdata/write_time_0000_200.json", `{"value":"04:00"}`),
+			writeRoute("inverter-1", 33, "testdata/write_time_0000_200.json", `{"value":"00:00"}`),
+			writeRoute("inverter-1", 34, "testdata/write_time_0000_200.json", `{"value":"00:00"}`),
+		)...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		err := cl.WriteSchedule(context.Background(), "inverter-1", &inverter.Schedule{
+			Kind: inverter.ScheduleCharge,
+			Slots: []*inverter.ScheduleSlot{
+				{Window: inverter.MustTimeWindow("16:00", "19:00"), SoC: ptr(80)},
+				{Window: inverter.MustTimeWindow("01:00", "04:00")},
+			},
+		})
+		require.NoError(t, err)
+		for _, id := range []int{64, 65, 101, 31, 32, 33, 34} {
+			require.Equal(t, 1, rrt.Calls(fmt.Sprintf("%s/inverter/inverter-1/settings/%d/write", baseURL, id)))
+		}
+	})
+
+	t.Run("too many slots", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(t, scheduleRoutes("inverter-1")...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		err := cl.WriteSchedule(context.Background(), "inverter-1", &inverter.Schedule{
+			Kind: inverter.ScheduleDischarge,
+			Slots: []*inverter.ScheduleSlot{
+				{Window: inverter.MustTimeWindow("16:00", "17:00")},
+				{Window: inverter.MustTimeWindow("17:00", "18:00")},
+				{Window: inverter.MustTimeWindow("18:00", "19:00")},
+			},
+		})
+		require.ErrorIs(t, err, inverter.ErrUnsupported)
+	})
+
+	t.Run("soc not supported", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(t, scheduleRoutes("inverter-1")...)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		err := cl.WriteSchedule(context.Background(), "inverter-1", &inverter.Schedule{
+			Kind:  inverter.ScheduleDischarge,
+			Slots: []*inverter.ScheduleSlot{{Window: inverter.MustTimeWindow("16:00", "19:00"), SoC: ptr(20)}},
+		})
+		require.ErrorIs(t, err, inverter.ErrUnsupported)
+	})
+}
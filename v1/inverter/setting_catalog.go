@@ -0,0 +1,329 @@
+package inverter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SettingRuleDateFormat = "date_format"
+	SettingRuleBetween    = "between"
+	SettingRuleIn         = "in"
+	SettingRuleBoolean    = "boolean"
+	SettingRuleInteger    = "integer"
+)
+
+// SettingRule is a single Laravel style validation rule such as "between:0,100".
+type SettingRule struct {
+	Name   string
+	Params []string
+
+	layout   string
+	min, max float64
+}
+
+func (r *SettingRule) String() string {
+	if len(r.Params) == 0 {
+		return r.Name
+	}
+	return r.Name + ":" + strings.Join(r.Params, ",")
+}
+
+// ParseSettingRule parses a validation rule as returned in Settings.ValidationRules.
+// Rules the client does not know how to check are kept but always pass.
+func ParseSettingRule(s string) (*SettingRule, error) {
+	name, params, _ := strings.Cut(strings.TrimSpace(s), ":")
+	r := &SettingRule{Name: name}
+	if params != "" {
+		r.Params = strings.Split(params, ",")
+	}
+
+	switch r.Name {
+	case SettingRuleDateFormat:
+		if params == "" {
+			return nil, fmt.Errorf("rule %q: missing format", s)
+		}
+		r.layout = phpDateLayout(params)
+	case SettingRuleBetween:
+		if len(r.Params) != 2 {
+			return nil, fmt.Errorf("rule %q: expected min,max", s)
+		}
+		var err error
+		if r.min, err = strconv.ParseFloat(r.Params[0], 64); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", s, err)
+		}
+		if r.max, err = strconv.ParseFloat(r.Params[1], 64); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", s, err)
+		}
+	case SettingRuleIn:
+		if len(r.Params) == 0 {
+			return nil, fmt.Errorf("rule %q: missing values", s)
+		}
+	}
+
+	return r, nil
+}
+
+// Check reports whether value satisfies the rule. value is expected to be a
+// JSON decoded value: string, float64 or bool.
+func (r *SettingRule) Check(value any) bool {
+	switch r.Name {
+	case SettingRuleDateFormat:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		t, err := time.Parse(r.layout, s)
+		return err == nil && t.Format(r.layout) == s
+	case SettingRuleBetween:
+		if n, ok := numeric(value); ok {
+			return n >= r.min && n <= r.max
+		}
+		if s, ok := value.(string); ok {
+			l := float64(len([]rune(s)))
+			return l >= r.min && l <= r.max
+		}
+		return false
+	case SettingRuleIn:
+		for _, p := range r.Params {
+			if matchesLiteral(value, p) {
+				return true
+			}
+		}
+		return false
+	case SettingRuleBoolean:
+		switch v := value.(type) {
+		case bool:
+			return true
+		case float64:
+			return v == 0 || v == 1
+		case string:
+			return v == "0" || v == "1" || v == "true" || v == "false"
+		}
+		return false
+	case SettingRuleInteger:
+		n, ok := numeric(value)
+		return ok && n == math.Trunc(n)
+	default:
+		return true
+	}
+}
+
+// SettingDefinition describes a setting and the rules its values must satisfy.
+type SettingDefinition struct {
+	ID         string
+	Name       string
+	Validation string
+	Rules      []*SettingRule
+}
+
+func (d *SettingDefinition) Validate(value any) error {
+	v, err := normalizeSettingValue(value)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range d.Rules {
+		if !r.Check(v) {
+			return &SettingValidationError{
+				SettingID:   d.ID,
+				SettingName: d.Name,
+				Value:       value,
+				Rule:        r.String(),
+				Message:     d.Validation,
+			}
+		}
+	}
+	return nil
+}
+
+// SettingValidationError is returned when a value is rejected locally, before
+// it is sent to the API.
+type SettingValidationError struct {
+	SettingID   string
+	SettingName string
+	Value       any
+	Rule        string
+	Message     string
+}
+
+func (e *SettingValidationError) Error() string {
+	return fmt.Sprintf(
+		"setting %s (%s): value %v does not satisfy %s: %s",
+		e.SettingID,
+		e.SettingName,
+		e.Value,
+		e.Rule,
+		e.Message,
+	)
+}
+
+// SettingCatalog indexes the settings available on one inverter.
+type SettingCatalog struct {
+	byID   map[string]*SettingDefinition
+	byName map[string][]*SettingDefinition
+}
+
+func NewSettingCatalog(res *ListSettingsResponse) (*SettingCatalog, error) {
+	cat := &SettingCatalog{
+		byID:   make(map[string]*SettingDefinition, len(res.Data)),
+		byName: make(map[string][]*SettingDefinition, len(res.Data)),
+	}
+
+	for _, s := range res.Data {
+		def := &SettingDefinition{
+			ID:         strconv.Itoa(s.ID),
+			Name:       s.Name,
+			Validation: s.Validation,
+		}
+		for _, raw := range s.ValidationRules {
+			// a malformed rule is skipped like an unknown one rather than
+			// making the whole catalog unusable
+			r, err := ParseSettingRule(raw)
+			if err != nil {
+				continue
+			}
+			def.Rules = append(def.Rules, r)
+		}
+		cat.byID[def.ID] = def
+		key := normalizeSettingName(def.Name)
+		cat.byName[key] = append(cat.byName[key], def)
+	}
+
+	return cat, nil
+}
+
+func (c *SettingCatalog) Setting(settingID string) (*SettingDefinition, bool) {
+	def, ok := c.byID[settingID]
+	return def, ok
+}
+
+// Settings returns every setting in the catalog ordered by ID.
+func (c *SettingCatalog) Settings() []*SettingDefinition {
+	defs := make([]*SettingDefinition, 0, len(c.byID))
+	for _, def := range c.byID {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		a, _ := strconv.Atoi(defs[i].ID)
+		b, _ := strconv.Atoi(defs[j].ID)
+		return a < b
+	})
+	return defs
+}
+
+func (c *SettingCatalog) Validate(settingID string, value any) error {
+	def, ok := c.byID[settingID]
+	if !ok {
+		return fmt.Errorf("setting %s: %w", settingID, ErrSettingNotFound)
+	}
+	return def.Validate(value)
+}
+
+// SettingCatalog returns the catalog for an inverter, fetching it with
+// ListSettings the first time the serial number is seen.
+func (c *Client) SettingCatalog(ctx context.Context, serialNumber string) (*SettingCatalog, error) {
+	c.catalogsMu.Lock()
+	cat, ok := c.catalogs[serialNumber]
+	c.catalogsMu.Unlock()
+	if ok {
+		return cat, nil
+	}
+
+	res, err := c.ListSettings(ctx, &ListSettingsArgs{InverterSerialNumber: serialNumber})
+	if err != nil {
+		return nil, err
+	}
+	cat, err = NewSettingCatalog(res)
+	if err != nil {
+		return nil, err
+	}
+
+	c.catalogsMu.Lock()
+	c.catalogs[serialNumber] = cat
+	c.catalogsMu.Unlock()
+
+	return cat, nil
+}
+
+func (c *Client) validateSetting(ctx context.Context, serialNumber string, settingID string, value any) error {
+	if !c.validateWrites {
+		return nil
+	}
+
+	cat, err := c.SettingCatalog(ctx, serialNumber)
+	if err != nil {
+		return err
+	}
+	return cat.Validate(settingID, value)
+}
+
+// normalizeSettingValue converts a Go value to what the API would see once
+// the value is encoded as JSON.
+func normalizeSettingValue(value any) (any, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func numeric(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func matchesLiteral(value any, literal string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == literal
+	case bool:
+		if v {
+			return literal == "1" || literal == "true"
+		}
+		return literal == "0" || literal == "false"
+	case float64:
+		n, err := strconv.ParseFloat(literal, 64)
+		return err == nil && n == v
+	}
+	return false
+}
+
+var phpDateReplacer = strings.NewReplacer(
+	"Y", "2006",
+	"y", "06",
+	"m", "01",
+	"n", "1",
+	"d", "02",
+	"j", "2",
+	"H", "15",
+	"G", "15",
+	"h", "03",
+	"g", "3",
+	"i", "04",
+	"s", "05",
+	"A", "PM",
+	"a", "pm",
+)
+
+// phpDateLayout converts the subset of PHP date format characters used by
+// the API into a Go time layout.
+func phpDateLayout(format string) string {
+	return phpDateReplacer.Replace(format)
+}
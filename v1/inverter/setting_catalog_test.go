@@ -0,0 +1,170 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestSettingCatalog_Validate(t *testing.T) {
+	t.Parallel()
+
+	cat, err := inverter.NewSettingCatalog(&inverter.ListSettingsResponse{
+		Data: []*inverter.Settings{
+			{ID: 24, Name: "Enable Eco Mode", ValidationRules: []string{"boolean"}},
+			{ID: 64, Name: "AC Charge 1 Start Time", ValidationRules: []string{"date_format:H:i"}},
+			{ID: 77, Name: "AC Charge Upper % Limit", ValidationRules: []string{"integer", "between:0,100"}},
+			{ID: 96, Name: "Battery Pause Mode", ValidationRules: []string{"in:0,1,2,3"}},
+			{ID: 99, Name: "Unknown Rule", ValidationRules: []string{"required"}},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		settingID string
+		value     any
+		valid     bool
+	}{
+		{name: "boolean true", settingID: "24", value: true, valid: true},
+		{name: "boolean one", settingID: "24", value: 1, valid: true},
+		{name: "boolean string", settingID: "24", value: "yes", valid: false},
+		{name: "time", settingID: "64", value: "09:05", valid: true},
+		{name: "time without padding", settingID: "64", value: "9:5", valid: false},
+		{name: "time out of range", settingID: "64", value: "25:00", valid: false},
+		{name: "time not a string", settingID: "64", value: 905, valid: false},
+		{name: "limit", settingID: "77", value: 100, valid: true},
+		{name: "limit above max", settingID: "77", value: 101, valid: false},
+		{name: "limit not an integer", settingID: "77", value: 50.5, valid: false},
+		{name: "in", settingID: "96", value: 3, valid: true},
+		{name: "not in", settingID: "96", value: 4, valid: false},
+		{name: "unknown rule", settingID: "99", value: "anything", valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := cat.Validate(tt.settingID, tt.value)
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *inverter.SettingValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.settingID, validationErr.SettingID)
+		})
+	}
+
+	t.Run("unknown setting", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, cat.Validate("1000", true), inverter.ErrSettingNotFound)
+	})
+}
+
+func TestNewSettingCatalog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("malformed rule", func(t *testing.T) {
+		t.Parallel()
+
+		cat, err := inverter.NewSettingCatalog(&inverter.ListSettingsResponse{
+			Data: []*inverter.Settings{
+				{ID: 77, ValidationRules: []string{"integer", "between:0", "in:"}},
+				{ID: 64, Name: "AC Charge 1 Start Time"},
+			},
+		})
+		require.NoError(t, err)
+
+		def, ok := cat.Setting("77")
+		require.True(t, ok)
+		require.Len(t, def.Rules, 1)
+		require.NoError(t, cat.Validate("77", 500))
+		require.ErrorIs(t, cat.Validate("77", 1.5), inverter.ErrValidation)
+
+		_, err = cat.Lookup(inverter.SettingNameChargeStart)
+		require.NoError(t, err)
+	})
+}
+
+func TestClient_WriteSettingWithValidation(t *testing.T) {
+	t.Parallel()
+
+	listURL := fmt.Sprintf("%s/inverter/%s/settings", baseURL, "inverter-1")
+	writeURL := fmt.Sprintf("%s/inverter/%s/settings/%s/write", baseURL, "inverter-1", "77")
+
+	t.Run("invalid value is not sent", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        listURL,
+				Path:       "testdata/list_settings_catalog_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:        writeURL,
+				Path:       "testdata/write_charge_limit_200.json",
+				StatusCode: http.StatusOK,
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithSettingValidation(),
+		)
+
+		_, err := cl.WriteSettingChargeLimit(
+			context.Background(),
+			inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 150),
+		)
+		var validationErr *inverter.SettingValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.ErrorIs(t, err, inverter.ErrValidation)
+		require.Equal(t, 0, rrt.Calls(writeURL))
+	})
+
+	t.Run("valid value is sent", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        listURL,
+				Path:       "testdata/list_settings_catalog_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:              writeURL,
+				Path:             "testdata/write_charge_limit_200.json",
+				StatusCode:       http.StatusOK,
+				ExpectedBodyJSON: "{ \"value\":100 }",
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+			inverter.WithSettingValidation(),
+		)
+
+		for range 2 {
+			data, err := cl.WriteSettingChargeLimit(
+				context.Background(),
+				inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 100),
+			)
+			require.NoError(t, err)
+			require.True(t, data.Data.Success)
+		}
+		require.Equal(t, 1, rrt.Calls(listURL))
+		require.Equal(t, 2, rrt.Calls(writeURL))
+	})
+}
@@ -0,0 +1,104 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SettingName is the canonical name of a setting as returned by ListSettings.
+type SettingName string
+
+const (
+	SettingNameChargeStart      SettingName = "AC Charge 1 Start Time"
+	SettingNameChargeEnd        SettingName = "AC Charge 1 End Time"
+	SettingNameChargeEnabled    SettingName = "AC Charge Enable"
+	SettingNameChargeLimit      SettingName = "AC Charge Upper % Limit"
+	SettingNameDischargeEnabled SettingName = "Enable DC Discharge"
+	SettingNameDischargeStart   SettingName = "DC Discharge 1 Start Time"
+	SettingNameDischargeEnd     SettingName = "DC Discharge 1 End Time"
+	SettingNameEcoModeEnabled   SettingName = "Enable Eco Mode"
+	SettingNamePauseBattery     SettingName = "Pause Battery"
+)
+
+// settingNameAliases lists the names used by other inverter families for the
+// same canonical setting.
+var settingNameAliases = map[SettingName][]SettingName{
+	SettingNameChargeStart:      {"AC Charge Start Time"},
+	SettingNameChargeEnd:        {"AC Charge End Time"},
+	SettingNameChargeEnabled:    {"Enable AC Charge"},
+	SettingNameChargeLimit:      {"AC Charge Upper Limit", "AC Charge 1 Upper SOC % Limit"},
+	SettingNameDischargeEnabled: {"DC Discharge Enable"},
+	SettingNameDischargeStart:   {"DC Discharge Start Time"},
+	SettingNameDischargeEnd:     {"DC Discharge End Time"},
+	SettingNameEcoModeEnabled:   {"Eco Mode"},
+	SettingNamePauseBattery:     {"Battery Pause Mode"},
+}
+
+func normalizeSettingName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Lookup finds a setting by name, trying the known aliases of canonical
+// names when the exact name is not present.
+func (c *SettingCatalog) Lookup(name SettingName) (*SettingDefinition, error) {
+	candidates := append([]SettingName{name}, settingNameAliases[name]...)
+	for _, candidate := range candidates {
+		defs := c.byName[normalizeSettingName(string(candidate))]
+		switch len(defs) {
+		case 0:
+			continue
+		case 1:
+			return defs[0], nil
+		default:
+			ids := make([]string, 0, len(defs))
+			for _, def := range defs {
+				ids = append(ids, def.ID)
+			}
+			return nil, fmt.Errorf("%q (ids %s): %w", name, strings.Join(ids, ", "), ErrAmbiguousSetting)
+		}
+	}
+	return nil, fmt.Errorf("%q: %w", name, ErrSettingNotFound)
+}
+
+// ResolveSettingID returns the ID of the named setting on the given inverter.
+// The catalog is fetched once per serial number and then cached.
+func (c *Client) ResolveSettingID(ctx context.Context, serialNumber string, name SettingName) (string, error) {
+	cat, err := c.SettingCatalog(ctx, serialNumber)
+	if err != nil {
+		return "", err
+	}
+
+	def, err := cat.Lookup(name)
+	if err != nil {
+		return "", fmt.Errorf("inverter %s: %w", serialNumber, err)
+	}
+	return def.ID, nil
+}
+
+func ReadSettingByName[T any](
+	ctx context.Context,
+	c *Client,
+	serialNumber string,
+	name SettingName,
+) (*ReadSettingResponse[T], error) {
+	id, err := c.ResolveSettingID(ctx, serialNumber, name)
+	if err != nil {
+		return nil, err
+	}
+	return ReadSetting[T](ctx, c, NewReadSettingArgs(serialNumber, id))
+}
+
+func WriteSettingByName[T any](
+	ctx context.Context,
+	c *Client,
+	serialNumber string,
+	name SettingName,
+	value T,
+) (*WriteSettingResponse[T], error) {
+	id, err := c.ResolveSettingID(ctx, serialNumber, name)
+	if err != nil {
+		return nil, err
+	}
+	return WriteSetting(ctx, c, NewWriteSettingArgs(serialNumber, id, value))
+}
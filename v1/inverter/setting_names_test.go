@@ -0,0 +1,99 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestSettingCatalog_Lookup(t *testing.T) {
+	t.Parallel()
+
+	cat, err := inverter.NewSettingCatalog(&inverter.ListSettingsResponse{
+		Data: []*inverter.Settings{
+			{ID: 64, Name: "AC Charge 1 Start Time"},
+			{ID: 101, Name: "AC Charge End Time"},
+			{ID: 200, Name: "Export Limit"},
+			{ID: 201, Name: "export  limit"},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("canonical name", func(t *testing.T) {
+		t.Parallel()
+
+		def, err := cat.Lookup(inverter.SettingNameChargeStart)
+		require.NoError(t, err)
+		require.Equal(t, "64", def.ID)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		t.Parallel()
+
+		def, err := cat.Lookup(inverter.SettingNameChargeEnd)
+		require.NoError(t, err)
+		require.Equal(t, "101", def.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cat.Lookup(inverter.SettingNameChargeLimit)
+		require.ErrorIs(t, err, inverter.ErrSettingNotFound)
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cat.Lookup("Export Limit")
+		require.ErrorIs(t, err, inverter.ErrAmbiguousSetting)
+	})
+}
+
+func TestClient_ResolveSettingID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		listURL := fmt.Sprintf("%s/inverter/%s/settings", baseURL, "inverter-1")
+		readURL := fmt.Sprintf("%s/inverter/%s/settings/%s/read", baseURL, "inverter-1", "77")
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        listURL,
+				Path:       "testdata/list_settings_catalog_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:        readURL,
+				Path:       "testdata/read_charge_limit_200.json",
+				StatusCode: http.StatusOK,
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		id, err := cl.ResolveSettingID(context.Background(), "inverter-1", inverter.SettingNameDischargeStart)
+		require.NoError(t, err)
+		require.Equal(t, inverter.DefaultSettingDischargeStart, id)
+
+		data, err := inverter.ReadSettingByName[int](
+			context.Background(),
+			cl,
+			"inverter-1",
+			inverter.SettingNameChargeLimit,
+		)
+		require.NoError(t, err)
+		require.Equal(t, 100, data.Data.Value)
+		require.Equal(t, 1, rrt.Calls(listURL))
+	})
+}
@@ -0,0 +1,190 @@
+package inverter
+
+import (
+	"fmt"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+// TimeOfDay is a wall clock time with minute precision, as used by the HH:mm
+// settings. The zero value is midnight.
+type TimeOfDay struct {
+	minutes int
+}
+
+func NewTimeOfDay(hour, minute int) (TimeOfDay, error) {
+	if hour < 0 || hour > 23 {
+		return TimeOfDay{}, fmt.Errorf("invalid hour %d", hour)
+	}
+	if minute < 0 || minute > 59 {
+		return TimeOfDay{}, fmt.Errorf("invalid minute %d", minute)
+	}
+	return TimeOfDay{minutes: hour*60 + minute}, nil
+}
+
+// ParseTimeOfDay parses s in the HH:mm format, both parts zero padded.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	if len(s) != 5 || s[2] != ':' || !isDigits(s[:2]) || !isDigits(s[3:]) {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: want HH:mm", s)
+	}
+
+	t, err := NewTimeOfDay(int(s[0]-'0')*10+int(s[1]-'0'), int(s[3]-'0')*10+int(s[4]-'0'))
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// MustParseTimeOfDay is like ParseTimeOfDay but panics on error.
+func MustParseTimeOfDay(s string) TimeOfDay {
+	t, err := ParseTimeOfDay(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TimeOfDayOf returns the wall clock time of t in its location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{minutes: t.Hour()*60 + t.Minute()}
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (t TimeOfDay) Hour() int {
+	return t.minutes / 60
+}
+
+func (t TimeOfDay) Minute() int {
+	return t.minutes % 60
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour(), t.Minute())
+}
+
+// Compare returns -1, 0 or +1 when t is before, equal to or after u.
+func (t TimeOfDay) Compare(u TimeOfDay) int {
+	switch {
+	case t.minutes < u.minutes:
+		return -1
+	case t.minutes > u.minutes:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (t TimeOfDay) Before(u TimeOfDay) bool {
+	return t.minutes < u.minutes
+}
+
+func (t TimeOfDay) After(u TimeOfDay) bool {
+	return t.minutes > u.minutes
+}
+
+// Add returns t+d wrapped around midnight, truncated to the minute.
+func (t TimeOfDay) Add(d time.Duration) TimeOfDay {
+	m := (t.minutes + int(d/time.Minute)) % minutesPerDay
+	if m < 0 {
+		m += minutesPerDay
+	}
+	return TimeOfDay{minutes: m}
+}
+
+// On returns t on the day of date, in the location of date.
+func (t TimeOfDay) On(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, date.Location())
+}
+
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *TimeOfDay) UnmarshalText(b []byte) error {
+	v, err := ParseTimeOfDay(string(b))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// TimeWindow is the half open interval [Start, End) of a charge or discharge
+// slot. End before Start means the window crosses midnight, End equal to
+// Start means the window is disabled.
+type TimeWindow struct {
+	Start TimeOfDay `json:"start"`
+	End   TimeOfDay `json:"end"`
+}
+
+func NewTimeWindow(start, end string) (TimeWindow, error) {
+	s, err := ParseTimeOfDay(start)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	e, err := ParseTimeOfDay(end)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	return TimeWindow{Start: s, End: e}, nil
+}
+
+// MustTimeWindow is like NewTimeWindow but panics on error.
+func MustTimeWindow(start, end string) TimeWindow {
+	w, err := NewTimeWindow(start, end)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func (w TimeWindow) Disabled() bool {
+	return w.Start == w.End
+}
+
+func (w TimeWindow) CrossesMidnight() bool {
+	return w.End.Before(w.Start)
+}
+
+func (w TimeWindow) Duration() time.Duration {
+	m := w.End.minutes - w.Start.minutes
+	if m < 0 {
+		m += minutesPerDay
+	}
+	return time.Duration(m) * time.Minute
+}
+
+func (w TimeWindow) Contains(t TimeOfDay) bool {
+	switch {
+	case w.Disabled():
+		return false
+	case w.CrossesMidnight():
+		return !t.Before(w.Start) || t.Before(w.End)
+	default:
+		return !t.Before(w.Start) && t.Before(w.End)
+	}
+}
+
+// Next returns the next occurrence of the window starting at or after from,
+// in the location of from.
+func (w TimeWindow) Next(from time.Time) (start, end time.Time) {
+	start = w.Start.On(from)
+	if start.Before(from) {
+		start = w.Start.On(from.AddDate(0, 0, 1))
+	}
+	return start, start.Add(w.Duration())
+}
+
+func (w TimeWindow) String() string {
+	return w.Start.String() + "-" + w.End.String()
+}
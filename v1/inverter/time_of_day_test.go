@@ -0,0 +1,171 @@
+package inverter_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		tod, err := inverter.ParseTimeOfDay("09:05")
+		require.NoError(t, err)
+		require.Equal(t, 9, tod.Hour())
+		require.Equal(t, 5, tod.Minute())
+		require.Equal(t, "09:05", tod.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, s := range []string{"", "9:5", "09:5", "25:00", "24:00", "12:60", "12-30", "ab:cd", "12:30:00"} {
+			_, err := inverter.ParseTimeOfDay(s)
+			require.Error(t, err, s)
+		}
+	})
+}
+
+func TestTimeOfDay_JSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := json.Marshal(inverter.MustTimeWindow("23:30", "05:00"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"start":"23:30","end":"05:00"}`, string(b))
+
+		var tod inverter.TimeOfDay
+		require.NoError(t, json.Unmarshal([]byte(`"16:00"`), &tod))
+		require.Equal(t, inverter.MustParseTimeOfDay("16:00"), tod)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var tod inverter.TimeOfDay
+		require.Error(t, json.Unmarshal([]byte(`"25:00"`), &tod))
+	})
+}
+
+func TestTimeOfDay_Compare(t *testing.T) {
+	t.Parallel()
+
+	a := inverter.MustParseTimeOfDay("01:00")
+	b := inverter.MustParseTimeOfDay("16:00")
+
+	require.True(t, a.Before(b))
+	require.True(t, b.After(a))
+	require.Equal(t, -1, a.Compare(b))
+	require.Equal(t, 0, a.Compare(a))
+	require.Equal(t, 1, b.Compare(a))
+	require.Equal(t, inverter.MustParseTimeOfDay("00:30"), inverter.MustParseTimeOfDay("23:45").Add(45*time.Minute))
+	require.Equal(t, inverter.MustParseTimeOfDay("23:30"), inverter.MustParseTimeOfDay("00:30").Add(-time.Hour))
+}
+
+func TestTimeWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same day", func(t *testing.T) {
+		t.Parallel()
+
+		w := inverter.MustTimeWindow("16:00", "19:00")
+		require.False(t, w.CrossesMidnight())
+		require.Equal(t, 3*time.Hour, w.Duration())
+		require.True(t, w.Contains(inverter.MustParseTimeOfDay("16:00")))
+		require.True(t, w.Contains(inverter.MustParseTimeOfDay("18:59")))
+		require.False(t, w.Contains(inverter.MustParseTimeOfDay("19:00")))
+		require.False(t, w.Contains(inverter.MustParseTimeOfDay("01:00")))
+	})
+
+	t.Run("crossing midnight", func(t *testing.T) {
+		t.Parallel()
+
+		w := inverter.MustTimeWindow("23:30", "05:30")
+		require.True(t, w.CrossesMidnight())
+		require.Equal(t, 6*time.Hour, w.Duration())
+		require.True(t, w.Contains(inverter.MustParseTimeOfDay("23:45")))
+		require.True(t, w.Contains(inverter.MustParseTimeOfDay("02:00")))
+		require.False(t, w.Contains(inverter.MustParseTimeOfDay("05:30")))
+		require.False(t, w.Contains(inverter.MustParseTimeOfDay("12:00")))
+
+		start, end := w.Next(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+		require.Equal(t, time.Date(2024, 5, 1, 23, 30, 0, 0, time.UTC), start)
+		require.Equal(t, time.Date(2024, 5, 2, 5, 30, 0, 0, time.UTC), end)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		w := inverter.TimeWindow{}
+		require.True(t, w.Disabled())
+		require.Equal(t, time.Duration(0), w.Duration())
+		require.False(t, w.Contains(inverter.MustParseTimeOfDay("00:00")))
+	})
+}
+
+func TestClient_ChargeWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		settingURL := func(id, op string) string {
+			return fmt.Sprintf("%s/inverter/%s/settings/%s/%s", baseURL, "inverter-1", id, op)
+		}
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{
+				URL:        fmt.Sprintf("%s/inverter/%s/settings", baseURL, "inverter-1"),
+				Path:       "testdata/list_settings_catalog_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:        settingURL(inverter.DefaultSettingChargeStart, "read"),
+				Path:       "testdata/read_time_1600_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:        settingURL(inverter.DefaultSettingChargeEnd, "read"),
+				Path:       "testdata/read_time_0000_200.json",
+				StatusCode: http.StatusOK,
+			},
+			&mockRoute{
+				URL:              settingURL(inverter.DefaultSettingChargeStart, "write"),
+				Path:             "testdata/write_charge_start_200.json",
+				StatusCode:       http.StatusOK,
+				ExpectedBodyJSON: `{"value":"23:30"}`,
+			},
+			&mockRoute{
+				URL:              settingURL(inverter.DefaultSettingChargeEnd, "write"),
+				Path:             "testdata/write_charge_end_200.json",
+				StatusCode:       http.StatusOK,
+				ExpectedBodyJSON: `{"value":"05:30"}`,
+			},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		w, err := cl.ReadChargeWindow(context.Background(), "inverter-1")
+		require.NoError(t, err)
+		require.Equal(t, inverter.MustTimeWindow("16:00", "00:00"), w)
+		require.True(t, w.CrossesMidnight())
+
+		err = cl.WriteChargeWindow(context.Background(), "inverter-1", inverter.MustTimeWindow("23:30", "05:30"))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,122 @@
+package inverter
+
+import (
+	"context"
+	"time"
+)
+
+type VerifyStatus int
+
+const (
+	// VerifyConfirmed means the inverter reports the written value.
+	VerifyConfirmed VerifyStatus = iota
+	// VerifyPending means the inverter still reports the previous value.
+	VerifyPending
+	// VerifyDiverged means the inverter reports a value that is neither the
+	// previous nor the written one.
+	VerifyDiverged
+)
+
+func (s VerifyStatus) String() string {
+	switch s {
+	case VerifyConfirmed:
+		return "confirmed"
+	case VerifyPending:
+		return "pending"
+	case VerifyDiverged:
+		return "diverged"
+	default:
+		return "unknown"
+	}
+}
+
+type VerifyOptions struct {
+	// Timeout bounds how long the setting is polled after the write.
+	Timeout time.Duration
+	// Interval is the delay between reads.
+	Interval time.Duration
+}
+
+func DefaultVerifyOptions() *VerifyOptions {
+	return &VerifyOptions{
+		Timeout:  30 * time.Second,
+		Interval: 2 * time.Second,
+	}
+}
+
+type VerifiedWriteResult[T comparable] struct {
+	Status   VerifyStatus
+	Previous T
+	Written  T
+	Observed T
+	Write    *WriteSettingResponse[T]
+	// Reads is the number of successful reads made after the write.
+	Reads int
+	// ReadErr is the last failed read, if any. A failed read leaves the
+	// status pending and polling carries on until the timeout.
+	ReadErr error
+}
+
+// WriteSettingVerified writes a setting and then reads it back until the
+// inverter reports the written value or opts.Timeout passes. A nil opts uses
+// DefaultVerifyOptions. Once the write is accepted the result is always
+// returned, along with ctx.Err() when ctx ends first.
+func WriteSettingVerified[T comparable](
+	ctx context.Context,
+	c *Client,
+	args *WriteSettingArgs[T],
+	opts *VerifyOptions,
+) (*VerifiedWriteResult[T], error) {
+	if opts == nil {
+		opts = DefaultVerifyOptions()
+	}
+	readArgs := NewReadSettingArgs(args.InverterSerialNumber, args.SettingID)
+
+	prev, err := ReadSetting[T](ctx, c, readArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	write, err := WriteSetting(ctx, c, args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifiedWriteResult[T]{
+		Status:   VerifyPending,
+		Previous: prev.Data.Value,
+		Written:  args.Value,
+		Observed: prev.Data.Value,
+		Write:    write,
+	}
+
+	deadline := time.Now().Add(opts.Timeout)
+	for {
+		if err := sleep(ctx, opts.Interval); err != nil {
+			return result, err
+		}
+
+		res, err := ReadSetting[T](ctx, c, readArgs)
+		switch {
+		case ctx.Err() != nil:
+			return result, ctx.Err()
+		case err != nil:
+			result.ReadErr = err
+		default:
+			result.Reads++
+			result.Observed = res.Data.Value
+			if result.Observed == result.Written {
+				result.Status = VerifyConfirmed
+				return result, nil
+			}
+		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+	}
+
+	if result.Observed != result.Previous {
+		result.Status = VerifyDiverged
+	}
+	return result, nil
+}
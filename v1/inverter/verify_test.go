@@ -0,0 +1,146 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func TestWriteSettingVerified(t *testing.T) {
+	t.Parallel()
+
+	readURL := fmt.Sprintf("%s/inverter/%s/settings/%s/read", baseURL, "inverter-1", inverter.DefaultSettingChargeLimit)
+	writeURL := fmt.Sprintf("%s/inverter/%s/settings/%s/write", baseURL, "inverter-1", inverter.DefaultSettingChargeLimit)
+	opts := &inverter.VerifyOptions{
+		Timeout:  5 * time.Millisecond,
+		Interval: time.Millisecond,
+	}
+
+	tests := []struct {
+		name     string
+		reads    []string
+		status   inverter.VerifyStatus
+		observed int
+	}{
+		{
+			name:     "confirmed",
+			reads:    []string{"read_charge_limit_200.json", "read_charge_limit_200.json", "read_charge_limit_80_200.json"},
+			status:   inverter.VerifyConfirmed,
+			observed: 80,
+		},
+		{
+			name:     "pending",
+			reads:    []string{"read_charge_limit_200.json"},
+			status:   inverter.VerifyPending,
+			observed: 100,
+		},
+		{
+			name:     "diverged",
+			reads:    []string{"read_charge_limit_200.json", "read_charge_limit_50_200.json"},
+			status:   inverter.VerifyDiverged,
+			observed: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			routes := []*mockRoute{
+				{
+					URL:              writeURL,
+					Path:             "testdata/write_charge_limit_80_200.json",
+					StatusCode:       http.StatusOK,
+					ExpectedBodyJSON: `{"value":80}`,
+				},
+			}
+			for _, read := range tt.reads {
+				routes = append(routes, &mockRoute{URL: readURL, Path: "testdata/" + read, StatusCode: http.StatusOK})
+			}
+			mockHTTPClient, rrt := newRouterClient(t, routes...)
+
+			cl := inverter.NewClient(
+				testToken,
+				inverter.WithHTTPClient(mockHTTPClient),
+			)
+
+			res, err := inverter.WriteSettingVerified(
+				context.Background(),
+				cl,
+				inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 80),
+				opts,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tt.status, res.Status)
+			require.Equal(t, 100, res.Previous)
+			require.Equal(t, 80, res.Written)
+			require.Equal(t, tt.observed, res.Observed)
+			require.True(t, res.Write.Data.Success)
+			require.Equal(t, 1, rrt.Calls(writeURL))
+		})
+	}
+
+	t.Run("failed read keeps polling", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, rrt := newRouterClient(
+			t,
+			&mockRoute{URL: writeURL, Path: "testdata/write_charge_limit_80_200.json", StatusCode: http.StatusOK},
+			&mockRoute{URL: readURL, Path: "testdata/read_charge_limit_200.json", StatusCode: http.StatusOK},
+			&mockRoute{URL: readURL, StatusCode: http.StatusInternalServerError},
+			&mockRoute{URL: readURL, Path: "testdata/read_charge_limit_80_200.json", StatusCode: http.StatusOK},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		res, err := inverter.WriteSettingVerified(
+			context.Background(),
+			cl,
+			inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 80),
+			&inverter.VerifyOptions{Timeout: time.Second, Interval: time.Millisecond},
+		)
+		require.NoError(t, err)
+		require.Equal(t, inverter.VerifyConfirmed, res.Status)
+		require.ErrorIs(t, res.ReadErr, inverter.ErrServer)
+		require.Equal(t, 1, res.Reads)
+		require.Equal(t, 3, rrt.Calls(readURL))
+	})
+
+	t.Run("context cancelled returns result", func(t *testing.T) {
+		t.Parallel()
+
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{URL: writeURL, Path: "testdata/write_charge_limit_80_200.json", StatusCode: http.StatusOK},
+			&mockRoute{URL: readURL, Path: "testdata/read_charge_limit_200.json", StatusCode: http.StatusOK},
+		)
+
+		cl := inverter.NewClient(
+			testToken,
+			inverter.WithHTTPClient(mockHTTPClient),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		res, err := inverter.WriteSettingVerified(
+			ctx,
+			cl,
+			inverter.NewWriteSettingArgs("inverter-1", inverter.DefaultSettingChargeLimit, 80),
+			&inverter.VerifyOptions{Timeout: time.Minute, Interval: time.Millisecond},
+		)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NotNil(t, res)
+		require.Equal(t, inverter.VerifyPending, res.Status)
+		require.True(t, res.Write.Data.Success)
+	})
+}
@@ -0,0 +1,160 @@
+package inverter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Deadband reports whether next differs enough from the last emitted sample
+// to be worth emitting.
+type Deadband func(prev, next *SystemData) bool
+
+// BatteryPercentDeadband emits when the battery percent moves by at least delta.
+func BatteryPercentDeadband(delta int) Deadband {
+	return func(prev, next *SystemData) bool {
+		if prev.Battery == nil || next.Battery == nil {
+			return prev.Battery != next.Battery
+		}
+		return abs(next.Battery.Percent-prev.Battery.Percent) >= delta
+	}
+}
+
+// SolarPowerDeadband emits when the solar power moves by at least watts.
+func SolarPowerDeadband(watts int) Deadband {
+	return func(prev, next *SystemData) bool {
+		if prev.Solar == nil || next.Solar == nil {
+			return prev.Solar != next.Solar
+		}
+		return abs(next.Solar.Power-prev.Solar.Power) >= watts
+	}
+}
+
+// GridPowerDeadband emits when the grid power moves by at least watts.
+func GridPowerDeadband(watts int) Deadband {
+	return func(prev, next *SystemData) bool {
+		if prev.Grid == nil || next.Grid == nil {
+			return prev.Grid != next.Grid
+		}
+		return abs(next.Grid.Power-prev.Grid.Power) >= watts
+	}
+}
+
+// ConsumptionDeadband emits when the consumption moves by at least watts.
+func ConsumptionDeadband(watts int) Deadband {
+	return func(prev, next *SystemData) bool {
+		return abs(next.Consumption-prev.Consumption) >= watts
+	}
+}
+
+// StatusDeadband emits whenever the inverter status changes.
+func StatusDeadband() Deadband {
+	return func(prev, next *SystemData) bool {
+		return prev.Status != next.Status
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	deadbands []Deadband
+	onError   func(error)
+}
+
+// WithDeadbands only emits samples for which at least one deadband reports a
+// significant change. Without deadbands every new sample is emitted.
+func WithDeadbands(deadbands ...Deadband) WatchOption {
+	return func(o *watchOptions) {
+		o.deadbands = append(o.deadbands, deadbands...)
+	}
+}
+
+// WithWatchErrorHandler is called with polling errors, which are otherwise
+// ignored until the next tick.
+func WithWatchErrorHandler(fn func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = fn
+	}
+}
+
+// Watch polls SystemDataLatest every interval and delivers new samples on the
+// returned channel. Samples whose Time has not advanced are skipped. The
+// channel is closed and the polling goroutine exits once ctx is done. It
+// returns an error when interval is not positive.
+func (c *Client) Watch(
+	ctx context.Context,
+	serialNumber string,
+	interval time.Duration,
+	opts ...WatchOption,
+) (<-chan *SystemData, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid poll interval %s: must be positive", interval)
+	}
+
+	conf := &watchOptions{
+		onError: func(error) {},
+	}
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	ch := make(chan *SystemData)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last *SystemData
+		for {
+			res, err := c.SystemDataLatest(ctx, &SystemDataLatestArgs{InverterSerialNumber: serialNumber})
+			switch {
+			case err != nil:
+				if ctx.Err() != nil {
+					return
+				}
+				conf.onError(err)
+			case res.Data != nil && conf.significant(last, res.Data):
+				select {
+				case ch <- res.Data:
+					last = res.Data
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (o *watchOptions) significant(prev, next *SystemData) bool {
+	if prev == nil {
+		return true
+	}
+	if !next.Time.After(prev.Time) {
+		return false
+	}
+	if len(o.deadbands) == 0 {
+		return true
+	}
+	for _, d := range o.deadbands {
+		if d(prev, next) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package inverter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/avasapollo/givenergy-go-client/v1/inverter"
+)
+
+func newWatchClient(t *testing.T) *inverter.Client {
+	t.Helper()
+
+	testURL := fmt.Sprintf("%s/inverter/%s/system-data/latest", baseURL, "inverter-1")
+	mockHTTPClient, _ := newRouterClient(
+		t,
+		&mockRoute{URL: testURL, Path: "testdata/system_data_latest_200.json", StatusCode: http.StatusOK},
+		&mockRoute{URL: testURL, Path: "testdata/system_data_latest_200.json", StatusCode: http.StatusOK},
+		&mockRoute{URL: testURL, Path: "testdata/system_data_latest_next_200.json", StatusCode: http.StatusOK},
+		&mockRoute{URL: testURL, Path: "testdata/system_data_latest_battery_200.json", StatusCode: http.StatusOK},
+	)
+
+	return inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient))
+}
+
+func TestClient_Watch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("skips samples that did not advance", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err := newWatchClient(t).Watch(ctx, "inverter-1", time.Millisecond)
+		require.NoError(t, err)
+
+		var times []time.Time
+		for data := range ch {
+			times = append(times, data.Time)
+			if len(times) == 3 {
+				cancel()
+			}
+		}
+		require.Equal(t, []time.Time{
+			time.Date(2024, 10, 17, 15, 22, 3, 0, time.UTC),
+			time.Date(2024, 10, 17, 15, 27, 3, 0, time.UTC),
+			time.Date(2024, 10, 17, 15, 32, 3, 0, time.UTC),
+		}, times)
+	})
+
+	t.Run("deadbands", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err := newWatchClient(t).Watch(
+			ctx,
+			"inverter-1",
+			time.Millisecond,
+			inverter.WithDeadbands(inverter.BatteryPercentDeadband(1)),
+		)
+		require.NoError(t, err)
+
+		var percents []int
+		for data := range ch {
+			percents = append(percents, data.Battery.Percent)
+			if len(percents) == 2 {
+				cancel()
+			}
+		}
+		require.Equal(t, []int{96, 98}, percents)
+	})
+
+	t.Run("errors are reported", func(t *testing.T) {
+		t.Parallel()
+
+		testURL := fmt.Sprintf("%s/inverter/%s/system-data/latest", baseURL, "inverter-1")
+		mockHTTPClient, _ := newRouterClient(
+			t,
+			&mockRoute{URL: testURL, StatusCode: http.StatusBadGateway},
+		)
+		cl := inverter.NewClient(testToken, inverter.WithHTTPClient(mockHTTPClient))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errs := make(chan error, 1)
+		ch, err := cl.Watch(ctx, "inverter-1", time.Millisecond, inverter.WithWatchErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}))
+		require.NoError(t, err)
+
+		require.ErrorIs(t, <-errs, inverter.ErrServer)
+		cancel()
+		for range ch {
+		}
+	})
+	t.Run("invalid interval", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := inverter.NewClient(testToken).Watch(context.Background(), "inverter-1", 0)
+		require.Error(t, err)
+	})
+}